@@ -2,11 +2,16 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -14,6 +19,7 @@ import (
 
 	"github.com/miniscruff/igloo/mathf"
 	"github.com/miniscruff/inuit/commands"
+	"github.com/miniscruff/inuit/components"
 )
 
 var (
@@ -34,7 +40,13 @@ func (s *{{.Name}}Scene) PostSetup() error {
 }
 
 func (s *{{.Name}}Scene) Update() {
+	s.tree.Update()
 }
+{{- range .Handlers }}
+
+func (s *{{$.Name}}Scene) {{ . }}() {
+}
+{{- end }}
 `))
 	genSceneTmpl = template.Must(template.New("genScene").Parse(`// Code generated by inuit DO NOT EDIT.
 
@@ -60,7 +72,11 @@ func (a *{{.Name}}Assets) Dispose() {
 
 func New{{.Name}}Assets(assetLoader *igloo.AssetLoader) (*{{.Name}}Assets, error) {
 	{{- range .Assets}}
+	{{- if .Decoder}}
+	{{.Name}}, err := components.LoadSound("{{.File}}", {{.Decoder}})
+	{{- else}}
 	{{.Name}}, err := assetLoader.{{.LoadMethod}}("{{.File}}")
+	{{- end}}
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +133,25 @@ type {{.Name}}Tree struct {
 	{{- range .Tree }}
 	{{- template "treeStruct" . }}
 	{{- end }}
+	Timelines *{{.Name}}Timelines
+	{{- if .Localized }}
+	locale string
+	{{- end }}
+	{{- if .Handlers }}
+	pointers []*components.Pointer
+	{{- end }}
+	{{- if .Breakpoints }}
+	breakpoints components.Breakpoints
+	{{- end }}
+	windowWidth  float64
+	windowHeight float64
+	onResize     func(width, height float64)
+}
+
+type {{.Name}}Timelines struct {
+	{{- range .Timelines }}
+	{{ .Name }} *components.Timeline
+	{{- end }}
 }
 
 func New{{.Name}}Tree(content *{{.Name}}Content) (*{{.Name}}Tree, error) {
@@ -127,14 +162,153 @@ func New{{.Name}}Tree(content *{{.Name}}Content) (*{{.Name}}Tree, error) {
 	{{range .Tree }}
 	{{ .Build }}
 	{{- end }}
+	{{- range .Buttons }}
+	{{ .Name }}.SetParents(
+		{{- range .Parents }}
+		{{ . }}.Visualer,
+		{{- end }}
+	)
+	{{- end }}
+	{{- if .Breakpoints }}
+
+	breakpoints := {{ .Breakpoints }}
+	breakpoints.Apply(windowWidth, windowHeight)
+	{{- end }}
 
 	return &{{.Name}}Tree{
 		{{- range .Tree }}
 		{{- template "retTree" . }}
 		{{- end }}
+		Timelines: &{{.Name}}Timelines{
+			{{- range .Timelines }}
+			{{ .Name }}: {{ .Build }},
+			{{- end }}
+		},
+		{{- if .Localized }}
+		locale: Locale(),
+		{{- end }}
+		{{- if .Breakpoints }}
+		breakpoints: breakpoints,
+		{{- end }}
+		windowWidth:  windowWidth,
+		windowHeight: windowHeight,
 	}, nil
 }
 
+// Update advances any visuals and timelines that change over time.
+func (t *{{.Name}}Tree) Update() {
+	{{- range .Updates }}
+	t.{{ . }}.Update()
+	{{- end }}
+	{{- range .Timelines }}
+	t.Timelines.{{ .Name }}.Update()
+	{{- end }}
+	{{- if .Handlers }}
+	for _, p := range t.pointers {
+		p.Update()
+	}
+	{{- end }}
+	{{- if .Localized }}
+
+	if t.locale != Locale() {
+		t.Localize()
+	}
+	{{- end }}
+}
+
+// Resize sizes our window sized visuals and applies our breakpoints
+// for a new window size, drawing resizes automatically.
+func (t *{{.Name}}Tree) Resize(width, height float64) {
+	t.windowWidth = width
+	t.windowHeight = height
+	{{- range .WindowSized }}
+	t.{{ . }}.Transform.SetWidth(width)
+	t.{{ . }}.Transform.SetHeight(height)
+	{{- end }}
+	{{- if .Breakpoints }}
+	t.breakpoints.Apply(width, height)
+	{{- end }}
+
+	if t.onResize != nil {
+		t.onResize(width, height)
+	}
+}
+{{- if .Localized }}
+
+// Localize sets the text of our labels from the current locale.
+func (t *{{.Name}}Tree) Localize() {
+	t.locale = Locale()
+	{{- range .Localized }}
+	t.{{ .Name }}.SetText(LocalizedText({{ printf "%q" .Key }}))
+	{{- end }}
+}
+{{- end }}
+
+{{- if .Handlers }}
+
+// {{.Name}}Handlers are the scene methods called by the events of our visuals.
+type {{.Name}}Handlers interface {
+	{{- range .Handlers }}
+	{{ . }}()
+	{{- end }}
+}
+
+// our base scene has to implement every handler our visuals use
+var _ {{.Name}}Handlers = (*{{.Name}}Scene)(nil)
+
+// SetHandlers calls handlers from the events of our visuals.
+func (t *{{.Name}}Tree) SetHandlers(handlers {{.Name}}Handlers) {
+	t.pointers = []*components.Pointer{
+		{{- range .Events }}
+		{
+			Visual: t.{{ .Name }}.Visualer,
+			{{- if .Parents }}
+			Parents: []*igloo.Visualer{
+				{{- range .Parents }}
+				t.{{ . }}.Visualer,
+				{{- end }}
+			},
+			{{- end }}
+			{{- range $event, $handler := .Handlers }}
+			{{ $event }}: handlers.{{ $handler }},
+			{{- end }}
+		},
+		{{- end }}
+	}
+}
+{{- end }}
+
+// ByTag returns every visual with a tag in tree order.
+func (t *{{.Name}}Tree) ByTag(tag string) []*igloo.Visualer {
+	switch tag {
+	{{- range .Tags }}
+	case {{ printf "%q" .Tag }}:
+		return []*igloo.Visualer{
+			{{- range .Visuals }}
+			t.{{ . }}.Visualer,
+			{{- end }}
+		}
+	{{- end }}
+	}
+
+	return nil
+}
+
+// Props returns the props of a visual by name.
+func (t *{{.Name}}Tree) Props(name string) map[string]string {
+	return {{.PropsVar}}[name]
+}
+
+var {{.PropsVar}} = map[string]map[string]string{
+	{{- range $name, $props := .Props }}
+	{{ printf "%q" $name }}: {
+		{{- range $key, $value := $props }}
+		{{ printf "%q" $key }}: {{ printf "%q" $value }},
+		{{- end }}
+	},
+	{{- end }}
+}
+
 func (s *{{.Name}}Scene) Setup(assetLoader *igloo.AssetLoader) error {
 	var err error
 
@@ -152,11 +326,23 @@ func (s *{{.Name}}Scene) Setup(assetLoader *igloo.AssetLoader) error {
 	if err != nil {
 		return err
 	}
+	{{- if .Handlers }}
+	s.tree.SetHandlers(s)
+	{{- end }}
+
+	// our base scene can optionally follow window size changes
+	if resizer, ok := any(s).(interface{ OnResize(width, height float64) }); ok {
+		s.tree.onResize = resizer.OnResize
+	}
 
 	return nil
 }
 
 func (s *{{.Name}}Scene) Draw(dest *ebiten.Image) {
+	// follow window size changes before laying out our visuals
+	if ww, wh := igloo.GetWindowSize(); float64(ww) != s.tree.windowWidth || float64(wh) != s.tree.windowHeight {
+		s.tree.Resize(float64(ww), float64(wh))
+	}
 	{{- range .Tree}}
 	s.tree.{{ .Name }}.Visualer.Layout(s.tree.{{ .Name }}.Transform, nil)
 	s.tree.{{ .Name }}.Visualer.Draw(dest)
@@ -172,8 +358,96 @@ func (s *{{.Name}}Scene) Dispose() {
 `))
 )
 
+// loopModeNames are the constants of each loop mode in generated code.
+var loopModeNames = map[components.LoopMode]string{
+	"":                           "components.AnimationLoop",
+	components.AnimationLoop:     "components.AnimationLoop",
+	components.AnimationOnce:     "components.AnimationOnce",
+	components.AnimationPingPong: "components.AnimationPingPong",
+}
+
+// soundDecoders are the ebiten function decoding each sound asset type.
+var soundDecoders = map[commands.AssetType]string{
+	commands.AssetWav: "wav.DecodeWithSampleRate",
+	commands.AssetOgg: "vorbis.DecodeWithSampleRate",
+	commands.AssetMp3: "mp3.DecodeWithSampleRate",
+}
+
+// timelinePropertyNames are the constants of each timeline property in generated code.
+var timelinePropertyNames = map[components.TimelineProperty]string{
+	components.PropertyX:        "components.PropertyX",
+	components.PropertyY:        "components.PropertyY",
+	components.PropertyRotation: "components.PropertyRotation",
+	components.PropertyAlpha:    "components.PropertyAlpha",
+	components.PropertyScaleX:   "components.PropertyScaleX",
+	components.PropertyScaleY:   "components.PropertyScaleY",
+	components.PropertyWidth:    "components.PropertyWidth",
+	components.PropertyHeight:   "components.PropertyHeight",
+	components.PropertyPivotX:   "components.PropertyPivotX",
+	components.PropertyPivotY:   "components.PropertyPivotY",
+
+	components.PropertyAnchorLeft:   "components.PropertyAnchorLeft",
+	components.PropertyAnchorRight:  "components.PropertyAnchorRight",
+	components.PropertyAnchorTop:    "components.PropertyAnchorTop",
+	components.PropertyAnchorBottom: "components.PropertyAnchorBottom",
+	components.PropertyOffsetLeft:   "components.PropertyOffsetLeft",
+	components.PropertyOffsetRight:  "components.PropertyOffsetRight",
+	components.PropertyOffsetTop:    "components.PropertyOffsetTop",
+	components.PropertyOffsetBottom: "components.PropertyOffsetBottom",
+}
+
+var stringsTmpl = template.Must(template.New("strings").Parse(`// Code generated by inuit DO NOT EDIT.
+
+package scenes
+
+var (
+	locale        = {{ printf "%q" .DefaultLocale }}
+	defaultLocale = {{ printf "%q" .DefaultLocale }}
+)
+
+// SetLocale changes the string table of our labels,
+// trees update their labels on their next update.
+func SetLocale(newLocale string) {
+	locale = newLocale
+}
+
+func Locale() string {
+	return locale
+}
+
+// LocalizedText is the text of a key in our locale, falling back to the
+// default locale and then the key itself.
+func LocalizedText(key string) string {
+	if text, found := stringTables[locale][key]; found {
+		return text
+	}
+
+	if text, found := stringTables[defaultLocale][key]; found {
+		return text
+	}
+
+	return key
+}
+
+var stringTables = map[string]map[string]string{
+	{{- range $locale, $table := .Tables }}
+	{{ printf "%q" $locale }}: {
+		{{- range $key, $text := $table }}
+		{{ printf "%q" $key }}: {{ printf "%q" $text }},
+		{{- end }}
+	},
+	{{- end }}
+}
+`))
+
+type StringsContext struct {
+	DefaultLocale string
+	Tables        commands.StringTables
+}
+
 type BaseSceneContext struct {
-	Name string
+	Name     string
+	Handlers []string
 }
 
 type GenAsset struct {
@@ -182,6 +456,8 @@ type GenAsset struct {
 	File       string
 	Dispose    string
 	LoadMethod string
+	// Decoder is the ebiten function decoding sound assets
+	Decoder string
 }
 
 type GenContent struct {
@@ -198,72 +474,493 @@ type GenTree struct {
 	Children []GenTree
 }
 
-type GeneratedSceneContext struct {
+type GenTag struct {
+	Tag     string
+	Visuals []string
+}
+
+type GenLocalized struct {
+	Name string
+	Key  string
+}
+
+// GenEvents are the handlers of a visual keyed by pointer field,
+// parents are the names of its ancestors from the root down.
+type GenEvents struct {
 	Name     string
-	Imports  []string
-	Assets   []GenAsset
-	Contents []GenContent
-	Tree     []GenTree
+	Parents  []string
+	Handlers map[string]string
+}
+
+// GenParented is a visual following the mouse within its ancestors.
+type GenParented struct {
+	Name    string
+	Parents []string
 }
 
-func generateBaseScene(w io.Writer, scene commands.SceneData) error {
+type GenTimeline struct {
+	Name  string
+	Build string
+}
+
+type GeneratedSceneContext struct {
+	Name      string
+	Imports   []string
+	Assets    []GenAsset
+	Contents  []GenContent
+	Tree      []GenTree
+	Timelines []GenTimeline
+	// Breakpoints is a literal of our breakpoints, empty without any
+	Breakpoints string
+	WindowSized []string
+	Localized   []GenLocalized
+	Handlers    []string
+	Events      []GenEvents
+	Buttons     []GenParented
+	Updates     []string
+	Tags        []GenTag
+	Props       map[string]map[string]string
+	PropsVar    string
+}
+
+func generateBaseScene(w io.Writer, scene commands.SceneData, prefabs map[string]*commands.SceneVisual) error {
+	visuals, err := commands.InlinePrefabs(scene.Visuals, prefabs)
+	if err != nil {
+		return err
+	}
+
 	ctx := BaseSceneContext{
-		Name: scene.Metadata.Name,
+		Name:     scene.Metadata.Name,
+		Handlers: commands.SceneHandlers(visuals),
 	}
 	return baseSceneTmpl.Execute(w, ctx)
 }
 
+// checkBaseSceneUpdate makes sure the Update of an existing base scene calls
+// s.tree.Update(), without it animations, timelines and pointers never change.
+func checkBaseSceneUpdate(path, name string) error {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || fn.Name.Name != "Update" || fn.Body == nil {
+			continue
+		}
+
+		recv, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+
+		if ident, ok := recv.X.(*ast.Ident); !ok || ident.Name != name+"Scene" {
+			continue
+		}
+
+		updatesTree := false
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			method, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || method.Sel.Name != "Update" {
+				return true
+			}
+
+			if field, ok := method.X.(*ast.SelectorExpr); ok && field.Sel.Name == "tree" {
+				updatesTree = true
+			}
+
+			return !updatesTree
+		})
+
+		if updatesTree {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%v: %vScene.Update must call s.tree.Update() to advance our visuals", path, name)
+}
+
 func generateGeneratedScene(
 	w io.Writer,
 	scene commands.SceneData,
 	assets map[string]commands.Asset,
 	content map[string]commands.Content,
 	metadata commands.Metadata,
+	prefabs map[string]*commands.SceneVisual,
 ) error {
+	visuals, err := commands.InlinePrefabs(scene.Visuals, prefabs)
+	if err != nil {
+		return err
+	}
+
+	imports := []string{
+		"image",
+		"image/color",
+		"github.com/hajimehoshi/ebiten/v2",
+		"golang.org/x/image/font/opentype",
+		"github.com/miniscruff/igloo",
+		"github.com/miniscruff/igloo/mathf",
+		"github.com/miniscruff/igloo/graphics",
+		"github.com/miniscruff/igloo/content",
+		"github.com/hajimehoshi/ebiten/v2/audio/mp3",
+		"github.com/hajimehoshi/ebiten/v2/audio/vorbis",
+		"github.com/hajimehoshi/ebiten/v2/audio/wav",
+		"github.com/miniscruff/inuit/components",
+	}
+	seenImports := make(map[string]struct{})
+	for _, i := range imports {
+		seenImports[i] = struct{}{}
+	}
+
 	var tree []GenTree
-	for _, v := range scene.Visuals {
-		tree = append(tree, buildTree(v))
+	for _, v := range visuals {
+		t, err := buildTree(v)
+		if err != nil {
+			return err
+		}
+
+		tree = append(tree, t)
+	}
+
+	for _, kind := range commands.VisualKinds() {
+		for _, i := range kind.Imports {
+			if _, found := seenImports[i]; !found {
+				seenImports[i] = struct{}{}
+				imports = append(imports, i)
+			}
+		}
+	}
+
+	timelines, err := buildTimelines(scene.Timelines)
+	if err != nil {
+		return err
+	}
+
+	breakpoints, err := buildBreakpoints(scene.Breakpoints, visuals)
+	if err != nil {
+		return err
 	}
 
 	ctx := GeneratedSceneContext{
-		Name: scene.Metadata.Name,
-		Imports: []string{
-			"github.com/hajimehoshi/ebiten/v2",
-			"golang.org/x/image/font/opentype",
-			"github.com/miniscruff/igloo",
-			"github.com/miniscruff/igloo/mathf",
-			"github.com/miniscruff/igloo/graphics",
-			"github.com/miniscruff/igloo/content",
-		},
-		Assets:   findAllAssets(assets, content, scene.Content),
-		Contents: findAllContent(content, scene.Content),
-		Tree:     tree,
+		Name:        scene.Metadata.Name,
+		Imports:     imports,
+		Assets:      findAllAssets(assets, content, scene.Content, scene.Sounds),
+		Contents:    findAllContent(content, scene.Content),
+		Tree:        tree,
+		Timelines:   timelines,
+		Breakpoints: breakpoints,
+		Localized:   findAllLocalized(visuals),
+		Handlers:    commands.SceneHandlers(visuals),
+		Events:      findAllEvents(visuals),
+		Buttons:     findAllButtons(visuals),
+		Updates:     findAllUpdates(visuals),
+		WindowSized: findAllWindowSized(visuals),
+		Tags:        findAllTags(visuals),
+		Props:       findAllProps(visuals),
+		PropsVar:    strings.ToLower(scene.Metadata.Name[:1]) + scene.Metadata.Name[1:] + "Props",
 	}
 	return genSceneTmpl.Execute(w, ctx)
 }
 
-func findAllAssets(assets map[string]commands.Asset, content map[string]commands.Content, contentKeys []string) []GenAsset {
-	var genAssets []GenAsset
-	seen := make(map[string]struct{})
+// buildBreakpoints creates a literal of our breakpoints, overrides set the
+// visuals already built in our tree and reset to the value saved in each visual.
+func buildBreakpoints(breakpoints []*commands.SceneBreakpoint, visuals []*commands.SceneVisual) (string, error) {
+	if len(breakpoints) == 0 {
+		return "", nil
+	}
 
-	for _, key := range contentKeys {
-		c := content[key]
-		var a GenAsset
-		switch c.Type {
-		case commands.ContentFont:
-			a.Name = c.Font.Asset
-			a.LoadMethod = "LoadOpenType"
-			a.Dispose = " = nil"
-			a.GoType = "*opentype.Font"
-		case commands.ContentSprite:
-			a.Name = c.Sprite.Asset
-			a.LoadMethod = "LoadImage"
-			a.Dispose = ".Dispose()"
-			a.GoType = "*ebiten.Image"
+	var b strings.Builder
+
+	writeFormat(&b, "components.Breakpoints{")
+	for _, bp := range breakpoints {
+		writeFormat(&b, "{")
+		condWrite(&b, bp.MinWidth != 0, "MinWidth: %v,", bp.MinWidth)
+		condWrite(&b, bp.MaxWidth != 0, "MaxWidth: %v,", bp.MaxWidth)
+		condWrite(&b, bp.MinHeight != 0, "MinHeight: %v,", bp.MinHeight)
+		condWrite(&b, bp.MaxHeight != 0, "MaxHeight: %v,", bp.MaxHeight)
+		writeFormat(&b, "Overrides: []components.Override{")
+		for _, o := range bp.Overrides {
+			property, found := timelinePropertyNames[o.Property]
+			if !found {
+				return "", fmt.Errorf("%v: unknown property %v", bp.Name, o.Property)
+			}
+
+			visual := commands.FindVisual(visuals, o.Visual)
+			if visual == nil {
+				return "", fmt.Errorf("%v: visual %v not found", bp.Name, o.Visual)
+			}
+
+			base, err := visual.PropertyValue(o.Property)
+			if err != nil {
+				return "", fmt.Errorf("%v: %w", bp.Name, err)
+			}
+
+			writeFormat(&b, "{")
+			writeFormat(&b, "Set: components.TrackSetter(%v.Visualer, %v),", o.Visual, property)
+			writeFormat(&b, "Base: %v,", base)
+			writeFormat(&b, "Value: %v,", o.Value)
+			writeFormat(&b, "},")
+		}
+		writeFormat(&b, "},")
+		writeFormat(&b, "},")
+	}
+	b.WriteString("}")
+
+	return b.String(), nil
+}
+
+// buildTimelines creates a timeline literal for each of our timelines,
+// tracks set the visuals already built in our tree.
+func buildTimelines(timelines []*commands.SceneTimeline) ([]GenTimeline, error) {
+	genTimelines := make([]GenTimeline, 0, len(timelines))
+	for _, timeline := range timelines {
+		var b strings.Builder
+
+		writeFormat(&b, "&components.Timeline{")
+		condWrite(&b, timeline.Loop, "Loop: true,")
+		writeFormat(&b, "Tracks: []components.TimelineTrack{")
+		for _, track := range timeline.Tracks {
+			property, found := timelinePropertyNames[track.Property]
+			if !found {
+				return nil, fmt.Errorf("%v: unknown property %v", timeline.Name, track.Property)
+			}
+
+			writeFormat(&b, "{")
+			writeFormat(&b, "Set: components.TrackSetter(%v.Visualer, %v),", track.Visual, property)
+			writeFormat(&b, "Keyframes: []components.Keyframe{")
+			for _, k := range track.Keyframes {
+				if k.Ease == "" {
+					writeFormat(&b, "{Time: %v, Value: %v},", k.Time, k.Value)
+					continue
+				}
+
+				if _, found := components.Eases[k.Ease]; !found {
+					return nil, fmt.Errorf("%v: unknown ease %v", timeline.Name, k.Ease)
+				}
+
+				writeFormat(&b, "{Time: %v, Value: %v, Ease: mathf.Ease%v},", k.Time, k.Value, k.Ease)
+			}
+			writeFormat(&b, "},")
+			writeFormat(&b, "},")
+		}
+		writeFormat(&b, "},")
+		b.WriteString("}")
+
+		genTimelines = append(genTimelines, GenTimeline{
+			Name:  timeline.Name,
+			Build: b.String(),
+		})
+	}
+
+	return genTimelines, nil
+}
+
+// eventFields are the pointer field each event sets.
+var eventFields = map[string]string{
+	commands.EventClick: "OnClick",
+	commands.EventEnter: "OnEnter",
+	commands.EventLeave: "OnLeave",
+}
+
+func findAllEvents(visuals []*commands.SceneVisual) []GenEvents {
+	var events []GenEvents
+
+	var walk func(visual *commands.SceneVisual, parents []string)
+	walk = func(visual *commands.SceneVisual, parents []string) {
+		if len(visual.Events) > 0 {
+			e := GenEvents{
+				Name:     visual.Name,
+				Parents:  parents,
+				Handlers: make(map[string]string),
+			}
+
+			for event, handler := range visual.Events {
+				if field, found := eventFields[event]; found {
+					e.Handlers[field] = handler
+				}
+			}
+
+			events = append(events, e)
+		}
+
+		childParents := append(parents[:len(parents):len(parents)], visual.Name)
+		for _, c := range visual.Children {
+			walk(c, childParents)
+		}
+	}
+
+	for _, v := range visuals {
+		walk(v, nil)
+	}
+
+	return events
+}
+
+// findAllButtons are the buttons within other visuals,
+// each needs its parents to ignore the mouse while they are hidden.
+func findAllButtons(visuals []*commands.SceneVisual) []GenParented {
+	var buttons []GenParented
+
+	var walk func(visual *commands.SceneVisual, parents []string)
+	walk = func(visual *commands.SceneVisual, parents []string) {
+		if visual.Type == commands.ButtonVisualType && len(parents) > 0 {
+			buttons = append(buttons, GenParented{
+				Name:    visual.Name,
+				Parents: parents,
+			})
+		}
+
+		childParents := append(parents[:len(parents):len(parents)], visual.Name)
+		for _, c := range visual.Children {
+			walk(c, childParents)
+		}
+	}
+
+	for _, v := range visuals {
+		walk(v, nil)
+	}
+
+	return buttons
+}
+
+func findAllLocalized(visuals []*commands.SceneVisual) []GenLocalized {
+	var localized []GenLocalized
+
+	var walk func(visual *commands.SceneVisual)
+	walk = func(visual *commands.SceneVisual) {
+		if visual.Type == commands.LabelVisualType && visual.Label.TextKey != "" {
+			localized = append(localized, GenLocalized{
+				Name: visual.Name,
+				Key:  visual.Label.TextKey,
+			})
+		}
+
+		for _, c := range visual.Children {
+			walk(c)
+		}
+	}
+
+	for _, v := range visuals {
+		walk(v)
+	}
+
+	return localized
+}
+
+func findAllUpdates(visuals []*commands.SceneVisual) []string {
+	var names []string
+
+	var walk func(visual *commands.SceneVisual)
+	walk = func(visual *commands.SceneVisual) {
+		if kind, err := commands.LookupVisualKind(visual.Type); err == nil && kind.Updates {
+			names = append(names, visual.Name)
+		}
+
+		for _, c := range visual.Children {
+			walk(c)
+		}
+	}
+
+	for _, v := range visuals {
+		walk(v)
+	}
+
+	return names
+}
+
+func findAllWindowSized(visuals []*commands.SceneVisual) []string {
+	var names []string
+
+	var walk func(visual *commands.SceneVisual)
+	walk = func(visual *commands.SceneVisual) {
+		if visual.UseWindowSize {
+			names = append(names, visual.Name)
+		}
+
+		for _, c := range visual.Children {
+			walk(c)
+		}
+	}
+
+	for _, v := range visuals {
+		walk(v)
+	}
+
+	return names
+}
+
+func findAllTags(visuals []*commands.SceneVisual) []GenTag {
+	var genTags []GenTag
+	tagIndex := make(map[string]int)
+
+	var walk func(visual *commands.SceneVisual)
+	walk = func(visual *commands.SceneVisual) {
+		for _, tag := range visual.Tags {
+			i, found := tagIndex[tag]
+			if !found {
+				i = len(genTags)
+				tagIndex[tag] = i
+				genTags = append(genTags, GenTag{Tag: tag})
+			}
+
+			genTags[i].Visuals = append(genTags[i].Visuals, visual.Name)
+		}
+
+		for _, c := range visual.Children {
+			walk(c)
+		}
+	}
+
+	for _, v := range visuals {
+		walk(v)
+	}
+
+	sort.Slice(genTags, func(i, j int) bool {
+		return genTags[i].Tag < genTags[j].Tag
+	})
+
+	return genTags
+}
+
+func findAllProps(visuals []*commands.SceneVisual) map[string]map[string]string {
+	props := make(map[string]map[string]string)
+
+	var walk func(visual *commands.SceneVisual)
+	walk = func(visual *commands.SceneVisual) {
+		if len(visual.Props) > 0 {
+			props[visual.Name] = visual.Props
+		}
+
+		for _, c := range visual.Children {
+			walk(c)
 		}
+	}
+
+	for _, v := range visuals {
+		walk(v)
+	}
+
+	return props
+}
+
+func findAllAssets(
+	assets map[string]commands.Asset,
+	content map[string]commands.Content,
+	contentKeys []string,
+	sounds []string,
+) []GenAsset {
+	var genAssets []GenAsset
+	seen := make(map[string]struct{})
 
+	addAsset := func(a GenAsset) {
 		if _, found := seen[a.Name]; found {
-			continue
+			return
 		}
 
 		seen[a.Name] = struct{}{}
@@ -272,6 +969,45 @@ func findAllAssets(assets map[string]commands.Asset, content map[string]commands
 		genAssets = append(genAssets, a)
 	}
 
+	imageAsset := func(name string) GenAsset {
+		return GenAsset{
+			Name:       name,
+			LoadMethod: "LoadImage",
+			Dispose:    ".Dispose()",
+			GoType:     "*ebiten.Image",
+		}
+	}
+
+	for _, key := range contentKeys {
+		c := content[key]
+		switch c.Type {
+		case commands.ContentFont:
+			addAsset(GenAsset{
+				Name:       c.Font.Asset,
+				LoadMethod: "LoadOpenType",
+				Dispose:    " = nil",
+				GoType:     "*opentype.Font",
+			})
+		case commands.ContentSprite:
+			addAsset(imageAsset(c.Sprite.Asset))
+		case commands.ContentSliced:
+			addAsset(imageAsset(c.Sliced.Asset))
+		case commands.ContentAnimation:
+			for _, frame := range c.Animation.Frames {
+				addAsset(imageAsset(frame.Asset))
+			}
+		}
+	}
+
+	for _, key := range sounds {
+		addAsset(GenAsset{
+			Name:    key,
+			Dispose: ".Dispose()",
+			GoType:  "*components.Sound",
+			Decoder: soundDecoders[assets[key].Type],
+		})
+	}
+
 	return genAssets
 }
 
@@ -311,8 +1047,33 @@ func findAllContent(content map[string]commands.Content, contentKeys []string) [
 			a.GoType = "*content.Sprite"
 			a.Create = func() string {
 				return fmt.Sprintf(`%v := &content.Sprite{
-	Image: assets.%v,
-}`, a.Name, c.Sprite.Asset)
+	Image: %v,
+}`, a.Name, genImage(c.Sprite.Asset, c.Sprite.Region))
+			}
+		case commands.ContentAnimation:
+			a.GoType = "*components.Animation"
+			a.Create = func() string {
+				var frames strings.Builder
+				for _, frame := range c.Animation.Frames {
+					fmt.Fprintf(&frames, "\t\t{Sprite: &content.Sprite{Image: %v}, Duration: %v},\n",
+						genImage(frame.Asset, frame.Region), frame.Duration,
+					)
+				}
+
+				return fmt.Sprintf(`%v := &components.Animation{
+	Loop: %v,
+	Frames: []components.AnimationFrame{
+%v	},
+}`, a.Name, loopModeNames[c.Animation.Loop], frames.String())
+			}
+		case commands.ContentSliced:
+			a.GoType = "*components.SlicedSprite"
+			a.Create = func() string {
+				borders := c.Sliced.Borders
+				return fmt.Sprintf(`%v := &components.SlicedSprite{
+	Image:   %v,
+	Borders: mathf.Sides{Left: %v, Right: %v, Top: %v, Bottom: %v},
+}`, a.Name, genImage(c.Sliced.Asset, c.Sliced.Region), borders.Left, borders.Right, borders.Top, borders.Bottom)
 			}
 		}
 
@@ -322,47 +1083,77 @@ func findAllContent(content map[string]commands.Content, contentKeys []string) [
 	return genContent
 }
 
-func buildTree(visual *commands.SceneVisual) GenTree {
+// genImage is the image of an asset limited to a region.
+func genImage(asset string, region *commands.Region) string {
+	if region == nil {
+		return "assets." + asset
+	}
+
+	return fmt.Sprintf(
+		"assets.%v.SubImage(image.Rect(%v, %v, %v, %v)).(*ebiten.Image)",
+		asset, region.X, region.Y, region.X+region.Width, region.Y+region.Height,
+	)
+}
+
+func buildTree(visual *commands.SceneVisual) (GenTree, error) {
 	t := GenTree{
 		Name: visual.Name,
 	}
 
-	switch visual.Type {
-	case commands.EmptyVisualType:
-		t.GoType = "*graphics.EmptyVisual"
-	case commands.SpriteVisualType:
-		t.GoType = "*graphics.SpriteVisual"
-	case commands.LabelVisualType:
-		t.GoType = "*graphics.LabelVisual"
+	kind, err := commands.LookupVisualKind(visual.Type)
+	if err != nil {
+		return t, fmt.Errorf("%v: %w", visual.Name, err)
 	}
 
+	t.GoType = kind.GoType
+
 	for _, c := range visual.Children {
-		t.Children = append(t.Children, buildTree(c))
+		child, err := buildTree(c)
+		if err != nil {
+			return t, err
+		}
+
+		t.Children = append(t.Children, child)
 	}
 
-	t.Build = genVisualBuild(t, visual, t.Children)
+	t.Build, err = genVisualBuild(t, kind, visual, t.Children)
 
-	return t
+	return t, err
 }
 
-func genVisualBuild(t GenTree, visual *commands.SceneVisual, children []GenTree) string {
+func genVisualBuild(
+	t GenTree,
+	kind *commands.VisualKind,
+	visual *commands.SceneVisual,
+	children []GenTree,
+) (string, error) {
 	var b strings.Builder
 
-	switch visual.Type {
-	case commands.EmptyVisualType:
-		writeFormat(&b, "%v := graphics.NewEmptyVisual()", t.Name)
-	case commands.SpriteVisualType:
-		writeFormat(&b, "%v := graphics.NewSpriteVisual()", t.Name)
-		writeFormat(&b, "%v.SetSprite(content.%v)", t.Name, visual.Sprite.Content)
-	case commands.LabelVisualType:
-		writeFormat(&b, "%v := graphics.NewLabelVisual()", t.Name)
-		writeFormat(&b, "%v.SetFont(content.%v)", t.Name, visual.Label.Content)
+	if err := kind.Generate(&b, t.Name, visual); err != nil {
+		return "", fmt.Errorf("%v: %w", t.Name, err)
 	}
 
 	if visual.Visible {
 		writeFormat(&b, "%v.SetVisible(true)", t.Name)
 	}
 
+	if visual.HasTint() {
+		tint, alpha, err := visual.Tint()
+		if err != nil {
+			return "", fmt.Errorf("%v: %w", t.Name, err)
+		}
+
+		writeFormat(&b,
+			"%v.ColorM.ScaleWithColor(color.NRGBA{R: %v, G: %v, B: %v, A: %v})",
+			t.Name, tint.R, tint.G, tint.B, tint.A,
+		)
+		condWrite(&b,
+			alpha != 1,
+			"%v.ColorM.Scale(1, 1, 1, %v)",
+			t.Name, alpha,
+		)
+	}
+
 	if visual.UseWindowSize {
 		writeFormat(&b, "%v.Transform.SetWidth(windowWidth)", t.Name)
 		writeFormat(&b, "%v.Transform.SetHeight(windowHeight)", t.Name)
@@ -384,6 +1175,11 @@ func genVisualBuild(t GenTree, visual *commands.SceneVisual, children []GenTree)
 		"%v.SetRotation(%v)",
 		t.Name, transform.Rotation,
 	)
+	condWrite(&b,
+		transform.ScaleOrOne() != mathf.Vec2{X: 1, Y: 1},
+		"%v.SetScale(mathf.Vec2{X: %v, Y: %v})",
+		t.Name, transform.ScaleOrOne().X, transform.ScaleOrOne().Y,
+	)
 	condWrite(&b,
 		transform.Anchors != mathf.SidesZero,
 		"%v.SetAnchors(mathf.Sides{Left: %v, Right: %v, Top: %v, Bottom: %v})",
@@ -415,17 +1211,35 @@ func genVisualBuild(t GenTree, visual *commands.SceneVisual, children []GenTree)
 		writeFormat(&b, c.Build)
 	}
 
+	insert := "InsertChild"
+	if kind.Container {
+		insert = "AddChild"
+	}
+
 	for _, c := range children {
-		writeFormat(&b, "%v.InsertChild(%v.Visualer)", t.Name, c.Name)
+		writeFormat(&b, "%v.%v(%v.Visualer)", t.Name, insert, c.Name)
 	}
 
-	return b.String()
+	return b.String(), nil
 }
 
 func main() {
+	reservedPath := flag.String("reserved", "", "write the names generated scenes use to a Go file and exit")
+	flag.Parse()
+
+	if *reservedPath != "" {
+		if err := generateReserved(*reservedPath); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
 	var assets map[string]commands.Asset
 	var content map[string]commands.Content
 	var metadata commands.Metadata
+	var prefabs map[string]*commands.SceneVisual
+	var stringTables commands.StringTables
 	var scenes []string
 	var err error
 
@@ -441,10 +1255,34 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if err = commands.LoadPrefabs(&prefabs); err != nil {
+		log.Fatal(err)
+	}
+
+	if err = commands.LoadStrings(&stringTables); err != nil {
+		log.Fatal(err)
+	}
+
 	if scenes, err = commands.ExistingScenes(); err != nil {
 		log.Fatal(err)
 	}
 
+	// generating a project with issues only fails later when compiling
+	issues, err := commands.ValidateProject(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(issues) > 0 {
+		fmt.Print(commands.FormatIssues(issues))
+		os.Exit(1)
+	}
+
+	// string tables are shared by every scene so only generated once
+	if err = generateStrings(metadata, stringTables); err != nil {
+		log.Fatal(err)
+	}
+
 	for _, fileName := range scenes {
 		var scene commands.SceneData
 		if err = commands.LoadSceneData(&scene, fileName+".json"); err != nil {
@@ -462,15 +1300,19 @@ func main() {
 				log.Fatal(err)
 			}
 
-			err = generateBaseScene(baseSceneWriter, scene)
+			err = generateBaseScene(baseSceneWriter, scene, prefabs)
 			if err != nil {
 				log.Fatal(err)
 			}
+		} else if len(scene.Visuals) > 0 {
+			if err = checkBaseSceneUpdate(baseScenePath, scene.Metadata.Name); err != nil {
+				log.Fatal(err)
+			}
 		}
 
 		var buffer bytes.Buffer
 
-		err = generateGeneratedScene(&buffer, scene, assets, content, metadata)
+		err = generateGeneratedScene(&buffer, scene, assets, content, metadata, prefabs)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -497,6 +1339,28 @@ func main() {
 	}
 }
 
+// generateStrings writes our string tables, it is written even without
+// tables as labels can use text keys before any table exists.
+func generateStrings(metadata commands.Metadata, stringTables commands.StringTables) error {
+	var buffer bytes.Buffer
+
+	err := stringsTmpl.Execute(&buffer, StringsContext{
+		DefaultLocale: metadata.LocaleOrDefault(),
+		Tables:        stringTables,
+	})
+	if err != nil {
+		return err
+	}
+
+	stringsPath := filepath.Join(metadata.ScenesPath, "strings_generated.go")
+	formattedBytes, err := imports.Process(stringsPath, buffer.Bytes(), nil)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(stringsPath, formattedBytes, 0644)
+}
+
 func condWrite(w io.StringWriter, cond bool, format string, args ...any) {
 	if !cond {
 		return
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/miniscruff/inuit/commands"
+)
+
+// probeName prefixes every name of our probe project, identifiers containing
+// it come from our probe and not from our templates.
+const probeName = "Probe"
+
+// probeProject uses every feature of our templates so the scene we generate
+// from it contains every identifier a generated scene can use.
+var probeProject = `{
+	"assets": {
+		"ProbeImage": {"type": "Image", "file": "probe.png"},
+		"ProbeFontAsset": {"type": "OpenType", "file": "probe.ttf"},
+		"ProbeWav": {"type": "Wav", "file": "probe.wav"},
+		"ProbeOgg": {"type": "Ogg", "file": "probe.ogg"},
+		"ProbeMp3": {"type": "Mp3", "file": "probe.mp3"}
+	},
+	"content": {
+		"ProbeSprite": {"type": "Sprite", "sprite": {"asset": "ProbeImage", "region": {"width": 1, "height": 1}}},
+		"ProbeSliced": {"type": "SlicedSprite", "sliced": {"asset": "ProbeImage", "borders": {"left": 1}}},
+		"ProbeFont": {"type": "Font", "font": {"asset": "ProbeFontAsset", "size": 1, "dpi": 1}},
+		"ProbeAnimation": {"type": "Animation", "animation": {"frames": [{"asset": "ProbeImage", "duration": 1}]}}
+	},
+	"scene": {
+		"metadata": {"name": "Probe"},
+		"content": ["ProbeSprite", "ProbeSliced", "ProbeFont", "ProbeAnimation"],
+		"sounds": ["ProbeWav", "ProbeOgg", "ProbeMp3"],
+		"visuals": [{
+			"name": "ProbeRoot",
+			"type": "Empty",
+			"useWindowSize": true,
+			"visible": true,
+			"tags": ["probe"],
+			"props": {"probe": "probe"},
+			"children": [
+				{"name": "ProbeSpriteVisual", "type": "Sprite", "visible": true, "color": "#ffffff", "alpha": 0.5,
+					"sprite": {"content": "ProbeSprite"},
+					"events": {"onClick": "ProbeClick", "onEnter": "ProbeEnter", "onLeave": "ProbeLeave"}},
+				{"name": "ProbeLabel", "type": "Label", "visible": true, "label": {"content": "ProbeFont", "textKey": "probe"}},
+				{"name": "ProbeSlicedVisual", "type": "SlicedSprite", "visible": true, "data": {"content": "ProbeSliced"}},
+				{"name": "ProbeAnimated", "type": "AnimatedSprite", "visible": true, "data": {"content": "ProbeAnimation"}},
+				{"name": "ProbeButton", "type": "Button", "visible": true,
+					"data": {"normal": "ProbeSprite", "over": "ProbeSprite", "clicked": "ProbeSprite", "font": "ProbeFont", "text": "probe"},
+					"events": {"onClick": "ProbeClick"}},
+				{"name": "ProbeHBox", "type": "HBox", "visible": true, "data": {"spacing": 1}},
+				{"name": "ProbeVBox", "type": "VBox", "visible": true, "data": {"spacing": 1}},
+				{"name": "ProbeGrid", "type": "Grid", "visible": true, "data": {"columns": 1}}
+			]
+		}],
+		"timelines": [{
+			"name": "ProbeTimeline",
+			"loop": true,
+			"tracks": [{"visual": "ProbeRoot", "property": "x", "keyframes": [{"time": 1, "value": 1, "ease": "InQuad"}]}]
+		}],
+		"breakpoints": [{
+			"name": "ProbeBreakpoint",
+			"minWidth": 1,
+			"overrides": [{"visual": "ProbeLabel", "property": "x", "value": 1}]
+		}]
+	},
+	"strings": {"en": {"probe": "probe"}}
+}`
+
+var reservedTmpl = template.Must(template.New("reserved").Parse(`// Code generated by inuit DO NOT EDIT.
+
+package commands
+
+// reservedNames are identifiers generated scenes already use,
+// a visual with one of these names would shadow or collide with it.
+var reservedNames = map[string]struct{}{
+	{{- range . }}
+	{{ printf "%q" . }}: {},
+	{{- end }}
+}
+`))
+
+// generateReserved writes the identifiers our templates emit for our probe
+// project as the reserved names validation rejects for visuals.
+func generateReserved(outputPath string) error {
+	var probe struct {
+		Assets  map[string]commands.Asset   `json:"assets"`
+		Content map[string]commands.Content `json:"content"`
+		Scene   commands.SceneData          `json:"scene"`
+		Strings commands.StringTables       `json:"strings"`
+	}
+
+	if err := json.Unmarshal([]byte(probeProject), &probe); err != nil {
+		return err
+	}
+
+	var sceneBuffer bytes.Buffer
+	err := generateGeneratedScene(&sceneBuffer, probe.Scene, probe.Assets, probe.Content, commands.Metadata{}, nil)
+	if err != nil {
+		return err
+	}
+
+	var stringsBuffer bytes.Buffer
+	err = stringsTmpl.Execute(&stringsBuffer, StringsContext{
+		DefaultLocale: commands.DefaultLocale,
+		Tables:        probe.Strings,
+	})
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]struct{})
+	for _, source := range [][]byte{sceneBuffer.Bytes(), stringsBuffer.Bytes()} {
+		if err := emittedIdentifiers(source, names); err != nil {
+			return err
+		}
+	}
+
+	reserved := make([]string, 0, len(names))
+	for name := range names {
+		if name != "_" && !strings.Contains(strings.ToLower(name), strings.ToLower(probeName)) {
+			reserved = append(reserved, name)
+		}
+	}
+	sort.Strings(reserved)
+
+	var buffer bytes.Buffer
+	if err := reservedTmpl.Execute(&buffer, reserved); err != nil {
+		return err
+	}
+
+	formattedBytes, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(outputPath, formattedBytes, 0644)
+}
+
+// emittedIdentifiers adds the identifiers of generated source a visual name
+// can collide with, imported packages, declarations and locals, along with
+// the fields and methods of our tree. Fields and keys of other types can not
+// collide with a visual and are skipped.
+func emittedIdentifiers(source []byte, names map[string]struct{}) error {
+	file, err := parser.ParseFile(token.NewFileSet(), "", source, 0)
+	if err != nil {
+		return fmt.Errorf("unable to parse generated source: %w", err)
+	}
+
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return err
+		}
+
+		names[importName(importPath)] = struct{}{}
+	}
+
+	treeType := probeName + "Tree"
+	skip := map[*ast.Ident]struct{}{
+		file.Name: {},
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.ImportSpec:
+			return false
+		case *ast.SelectorExpr:
+			skip[n.Sel] = struct{}{}
+		case *ast.KeyValueExpr:
+			if key, ok := n.Key.(*ast.Ident); ok {
+				skip[key] = struct{}{}
+			}
+		case *ast.StructType:
+			for _, field := range n.Fields.List {
+				for _, name := range field.Names {
+					skip[name] = struct{}{}
+				}
+			}
+		case *ast.TypeSpec:
+			if structType, ok := n.Type.(*ast.StructType); ok && n.Name.Name == treeType {
+				for _, field := range structType.Fields.List {
+					for _, name := range field.Names {
+						names[name.Name] = struct{}{}
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if n.Recv != nil {
+				skip[n.Name] = struct{}{}
+				if recv, ok := n.Recv.List[0].Type.(*ast.StarExpr); ok {
+					if ident, ok := recv.X.(*ast.Ident); ok && ident.Name == treeType {
+						names[n.Name.Name] = struct{}{}
+					}
+				}
+			}
+		case *ast.Ident:
+			if _, skipped := skip[n]; !skipped {
+				names[n.Name] = struct{}{}
+			}
+		}
+
+		return true
+	})
+
+	return nil
+}
+
+// importName is the package name of an import path,
+// skipping major version suffixes such as ebiten/v2.
+func importName(importPath string) string {
+	name := path.Base(importPath)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(importPath))
+	}
+
+	return name
+}
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/miniscruff/igloo"
+	"github.com/miniscruff/igloo/content"
+	"github.com/miniscruff/inuit/components"
+)
+
+// AnimatedSpriteVisualData is kept in the data field of animated sprite visuals.
+type AnimatedSpriteVisualData struct {
+	Content string `json:"content"`
+}
+
+// NewAnimation creates the frames of animation content from loaded image assets.
+func NewAnimation(animation AnimationContent, assets map[string]any) (*components.Animation, error) {
+	frames := make([]components.AnimationFrame, 0, len(animation.Frames))
+	for _, frame := range animation.Frames {
+		img, ok := assets[frame.Asset].(*ebiten.Image)
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", errContentNotFound, frame.Asset)
+		}
+
+		frames = append(frames, components.AnimationFrame{
+			Sprite: &content.Sprite{
+				Image: SubImage(img, frame.Region),
+			},
+			Duration: frame.Duration,
+		})
+	}
+
+	return &components.Animation{
+		Frames: frames,
+		Loop:   animation.Loop,
+	}, nil
+}
+
+func animatedSpriteVisualKind() *VisualKind {
+	return &VisualKind{
+		Type: AnimatedSpriteVisualType,
+		NewData: func() any {
+			return &AnimatedSpriteVisualData{}
+		},
+		Content: func(visual *SceneVisual) ([]ContentRef, error) {
+			var data AnimatedSpriteVisualData
+			if err := visual.DecodeData(&data); err != nil {
+				return nil, err
+			}
+
+			return []ContentRef{{Key: data.Content, Type: ContentAnimation}}, nil
+		},
+		New: func(visual *SceneVisual, contentMap map[string]any) (any, *igloo.Visualer, error) {
+			var data AnimatedSpriteVisualData
+			if err := visual.DecodeData(&data); err != nil {
+				return nil, nil, err
+			}
+
+			animation, err := LookupContent[*components.Animation](contentMap, data.Content)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			animatedVis := components.NewAnimatedSpriteVisual()
+			animatedVis.SetAnimation(animation)
+			return animatedVis, animatedVis.Visualer, nil
+		},
+		GoType:  "*components.AnimatedSpriteVisual",
+		Imports: []string{"github.com/miniscruff/inuit/components"},
+		Generate: func(w io.StringWriter, name string, visual *SceneVisual) error {
+			var data AnimatedSpriteVisualData
+			if err := visual.DecodeData(&data); err != nil {
+				return err
+			}
+
+			WriteFormat(w, "%v := components.NewAnimatedSpriteVisual()", name)
+			WriteFormat(w, "%v.SetAnimation(content.%v)", name, data.Content)
+			return nil
+		},
+		Updates: true,
+		Commands: func() []*Command {
+			return []*Command{
+				{
+					Key: "content",
+					Help: func() string {
+						return "change the animation content of our visual"
+					},
+					Suggestions: ContentSuggestions(ContentAnimation),
+					Validations: []Validation{
+						RequiresVisualType(AnimatedSpriteVisualType),
+						RequiredArgs(1),
+					},
+					Mutates: true,
+					Run: func(editor Editor, args []string) (string, error) {
+						animation, err := LookupContent[*components.Animation](editor.Content(), args[0])
+						if err != nil {
+							return "", err
+						}
+
+						if err := editor.Visual().EncodeData(AnimatedSpriteVisualData{Content: args[0]}); err != nil {
+							return "", err
+						}
+
+						editor.Visual().Instance.(*components.AnimatedSpriteVisual).SetAnimation(animation)
+						return "", nil
+					},
+				},
+			}
+		},
+	}
+}
+
+// playable is any visual instance the play and pause commands control.
+type playable interface {
+	Play()
+	Pause()
+}
+
+func playCommand() *Command {
+	return &Command{
+		Key: "play",
+		Help: func() string {
+			return "play animations in the editor, only our visual if one is active"
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			eachPlayable(editor, playable.Play)
+			return "", nil
+		},
+	}
+}
+
+func pauseCommand() *Command {
+	return &Command{
+		Key: "pause",
+		Help: func() string {
+			return "pause animations in the editor, only our visual if one is active"
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			eachPlayable(editor, playable.Pause)
+			return "", nil
+		},
+	}
+}
+
+func eachPlayable(editor Editor, action func(playable)) {
+	visuals := editor.SceneData().Visuals
+	if editor.Visual() != nil {
+		visuals = []*SceneVisual{editor.Visual()}
+	}
+
+	var walk func(visual *SceneVisual)
+	walk = func(visual *SceneVisual) {
+		if p, ok := visual.Instance.(playable); ok {
+			action(p)
+		}
+
+		for _, c := range visual.Children {
+			walk(c)
+		}
+	}
+
+	for _, v := range visuals {
+		walk(v)
+	}
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+const flattenFlag = "--flatten"
+
+var flattenOptions = []string{flattenFlag}
+
+func assetCommand() *Command {
+	return &Command{
+		Key: "asset",
+		Help: func() string {
+			return "list or import assets"
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			var assets map[string]Asset
+			if err := LoadAssets(&assets); err != nil {
+				return "", err
+			}
+
+			var b strings.Builder
+			for _, key := range sortedKeys(assets) {
+				WriteFormat(&b, "%v: %v %v", key, assets[key].Type, assets[key].File)
+			}
+
+			return b.String(), nil
+		},
+		Subcommands: []*Command{
+			{
+				Key: "import-pxo",
+				Help: func() string {
+					return "import the layers of a pixelorama project, --flatten imports frames instead"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 2 {
+						return nil
+					}
+
+					return Filter(partial[1], flattenOptions, StringUnchanged)
+				},
+				Validations: []Validation{
+					MinArgs(1),
+				},
+				Run: func(editor Editor, args []string) (string, error) {
+					result, err := ImportPxoAction(*editor.Metadata(), args)
+					if err != nil {
+						return "", err
+					}
+
+					return result, editor.ReloadContent()
+				},
+			},
+			{
+				Key: "import-sound",
+				Help: func() string {
+					return "import a wav, ogg or mp3 file as a sound asset: import-sound <file> [key]"
+				},
+				Validations: []Validation{
+					MinArgs(1),
+				},
+				Run: func(editor Editor, args []string) (string, error) {
+					key := ""
+					if len(args) > 1 {
+						key = args[1]
+					}
+
+					key, err := ImportSound(*editor.Metadata(), args[0], key)
+					if err != nil {
+						return "", err
+					}
+
+					if err := editor.ReloadContent(); err != nil {
+						return "", err
+					}
+
+					return fmt.Sprintf("imported %v, use sound add to use it", key), nil
+				},
+			},
+		},
+	}
+}
+
+// ImportPxoAction imports a pixelorama project from our arguments,
+// shared by the editor and command line.
+func ImportPxoAction(metadata Metadata, args []string) (string, error) {
+	flatten := len(args) > 1 && args[1] == flattenFlag
+
+	keys, err := ImportPxo(metadata, args[0], flatten)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("imported %v images, use content add to use them:\n%v",
+		len(keys),
+		strings.Join(keys, " "),
+	), nil
+}
@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var (
+	errUnknownSheetFormat = errors.New("unknown sprite sheet format")
+	errRotatedFrame       = errors.New("rotated frames are not supported")
+	errKeyInUse           = errors.New("key already in use")
+)
+
+// SpriteSheet is an image along with named regions within it.
+type SpriteSheet struct {
+	Image  string
+	Frames []SheetFrame
+}
+
+type SheetFrame struct {
+	Name   string
+	Region Region
+}
+
+type sheetRect struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type sheetFrame struct {
+	Filename string    `json:"filename"`
+	Frame    sheetRect `json:"frame"`
+	Rotated  bool      `json:"rotated"`
+}
+
+type sheetFile struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		Image string `json:"image"`
+	} `json:"meta"`
+}
+
+// ParseSpriteSheet reads the JSON hash and JSON array formats,
+// as exported by TexturePacker, Aseprite and most other sprite packers.
+// Trimmed frames keep only their packed region.
+func ParseSpriteSheet(data []byte) (SpriteSheet, error) {
+	var file sheetFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return SpriteSheet{}, err
+	}
+
+	if len(file.Frames) == 0 || file.Meta.Image == "" {
+		return SpriteSheet{}, errUnknownSheetFormat
+	}
+
+	var frames []sheetFrame
+	if err := json.Unmarshal(file.Frames, &frames); err != nil {
+		var hash map[string]sheetFrame
+		if err := json.Unmarshal(file.Frames, &hash); err != nil {
+			return SpriteSheet{}, errUnknownSheetFormat
+		}
+
+		for _, name := range sortedKeys(hash) {
+			frame := hash[name]
+			frame.Filename = name
+			frames = append(frames, frame)
+		}
+	}
+
+	sheet := SpriteSheet{
+		Image: file.Meta.Image,
+	}
+
+	for _, frame := range frames {
+		if frame.Rotated {
+			return SpriteSheet{}, fmt.Errorf("%w: %v", errRotatedFrame, frame.Filename)
+		}
+
+		sheet.Frames = append(sheet.Frames, SheetFrame{
+			Name: frame.Filename,
+			Region: Region{
+				X:      frame.Frame.X,
+				Y:      frame.Frame.Y,
+				Width:  frame.Frame.W,
+				Height: frame.Frame.H,
+			},
+		})
+	}
+
+	return sheet, nil
+}
+
+// ContentKey converts a file or frame name into a key usable in generated code,
+// "hero/walk_0.png" becomes "HeroWalk0".
+func ContentKey(name string) string {
+	name = strings.TrimSuffix(name, path.Ext(name))
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var key strings.Builder
+	for _, part := range parts {
+		runes := []rune(part)
+		key.WriteRune(unicode.ToUpper(runes[0]))
+		key.WriteString(string(runes[1:]))
+	}
+
+	if key.Len() == 0 || unicode.IsDigit([]rune(key.String())[0]) {
+		return "Sprite" + key.String()
+	}
+
+	return key.String()
+}
+
+// ImportSpriteSheet adds the image of a sprite sheet as an asset and a sprite
+// content for every frame, importing again updates the existing content.
+// The sheet path is relative to our assets path and the new content keys
+// are returned in sheet order.
+func ImportSpriteSheet(metadata Metadata, sheetPath, prefix string) ([]string, error) {
+	sheetBytes, err := os.ReadFile(filepath.Join(metadata.AssetsPath, sheetPath))
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := ParseSpriteSheet(sheetBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", sheetPath, err)
+	}
+
+	var (
+		assets  map[string]Asset
+		content map[string]Content
+	)
+
+	if err := LoadAssets(&assets); err != nil {
+		return nil, err
+	}
+	if err := LoadContent(&content); err != nil {
+		return nil, err
+	}
+
+	imageFile := path.Join(path.Dir(filepath.ToSlash(sheetPath)), sheet.Image)
+	assetKey, err := addImageAsset(assets, imageFile, prefix+ContentKey(path.Base(imageFile)))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(sheet.Frames))
+	for _, frame := range sheet.Frames {
+		key := prefix + ContentKey(frame.Name)
+		if contains(keys, key) {
+			return nil, fmt.Errorf("%w: frame %v is also %v", errKeyInUse, frame.Name, key)
+		}
+
+		region := frame.Region
+		if err := setSpriteContent(content, key, assetKey, &region); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err := SaveAssets(assets); err != nil {
+		return nil, err
+	}
+
+	return keys, SaveContent(content)
+}
+
+// addImageAsset registers an image file as an asset, reusing the key
+// of an existing asset of the same file.
+func addImageAsset(assets map[string]Asset, file, key string) (string, error) {
+	for _, k := range sortedKeys(assets) {
+		if assets[k].Type == AssetImage && assets[k].File == file {
+			return k, nil
+		}
+	}
+
+	if existing, found := assets[key]; found {
+		return "", fmt.Errorf("%w: asset %v uses file %v", errKeyInUse, key, existing.File)
+	}
+
+	assets[key] = Asset{
+		Type: AssetImage,
+		File: file,
+	}
+
+	return key, nil
+}
+
+// setSpriteContent adds or updates sprite content,
+// existing content of another type or asset is never replaced.
+func setSpriteContent(content map[string]Content, key, asset string, region *Region) error {
+	if existing, found := content[key]; found && existing.Sprite.Asset != asset {
+		return fmt.Errorf("%w: content %v uses asset %v", errKeyInUse, key, existing.Sprite.Asset)
+	}
+
+	content[key] = Content{
+		Type: ContentSprite,
+		Sprite: SpriteContent{
+			BaseContent: BaseContent{Asset: asset},
+			Region:      region,
+		},
+	}
+
+	return nil
+}
+
+// SubImage returns the region of an image, nil regions use the whole image.
+func SubImage(img *ebiten.Image, region *Region) *ebiten.Image {
+	if region == nil {
+		return img
+	}
+
+	return img.SubImage(region.Rect()).(*ebiten.Image)
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const backupTimeFormat = "20060102-150405.000"
+
+var errBackupNotFound = errors.New("backup not found")
+
+func sceneBackupDir(path string) string {
+	return filepath.Join(InternalDir, BackupsDir, strings.TrimSuffix(path, ".json"))
+}
+
+// ListSceneBackups returns the backup names of a scene, newest first.
+func ListSceneBackups(path string) ([]string, error) {
+	entries, err := os.ReadDir(sceneBackupDir(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failure to read backups: %w", err)
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		backups = append(backups, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups, nil
+}
+
+// RestoreSceneBackup replaces a scene on disk with one of its backups,
+// the replaced version is backed up as well so a restore can be undone.
+func RestoreSceneBackup(path, backup string, keep int) error {
+	backupBytes, err := os.ReadFile(filepath.Join(sceneBackupDir(path), backup+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %v", errBackupNotFound, backup)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := backupScene(path, keep); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(InternalDir, path), backupBytes)
+}
+
+// backupScene copies the current scene file into the backups folder and
+// removes all but the newest keep backups.
+func backupScene(path string, keep int) error {
+	sceneBytes, err := os.ReadFile(filepath.Join(InternalDir, path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	dir := sceneBackupDir(path)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	name := time.Now().Format(backupTimeFormat) + ".json"
+	if err := writeFileAtomic(filepath.Join(dir, name), sceneBytes); err != nil {
+		return err
+	}
+
+	backups, err := ListSceneBackups(path)
+	if err != nil {
+		return err
+	}
+
+	for i := keep; i < len(backups); i++ {
+		if err := os.Remove(filepath.Join(dir, backups[i]+".json")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
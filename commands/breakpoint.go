@@ -0,0 +1,426 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/miniscruff/inuit/components"
+)
+
+var errBreakpointNotFound = errors.New("breakpoint not found")
+
+// breakpointBounds are the bounds of a breakpoint in the order of our add command.
+var breakpointBounds = []string{"minWidth", "maxWidth", "minHeight", "maxHeight"}
+
+func (s *SceneData) Breakpoint(name string) (*SceneBreakpoint, error) {
+	for _, b := range s.Breakpoints {
+		if b.Name == name {
+			return b, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %v", errBreakpointNotFound, name)
+}
+
+// SetOverride adds or replaces the override of a visual property.
+func (b *SceneBreakpoint) SetOverride(visual string, property components.TimelineProperty, value float64) {
+	for _, o := range b.Overrides {
+		if o.Visual == visual && o.Property == property {
+			o.Value = value
+			return
+		}
+	}
+
+	b.Overrides = append(b.Overrides, &BreakpointOverride{
+		Visual:   visual,
+		Property: property,
+		Value:    value,
+	})
+}
+
+// RemoveOverride removes the override of a visual property if there is one.
+func (b *SceneBreakpoint) RemoveOverride(visual string, property components.TimelineProperty) {
+	kept := b.Overrides[:0]
+	for _, o := range b.Overrides {
+		if o.Visual != visual || o.Property != property {
+			kept = append(kept, o)
+		}
+	}
+
+	b.Overrides = kept
+}
+
+// Bounds describes the window sizes our breakpoint matches.
+func (b *SceneBreakpoint) Bounds() string {
+	var parts []string
+	for i, bound := range []float64{b.MinWidth, b.MaxWidth, b.MinHeight, b.MaxHeight} {
+		if bound != 0 {
+			parts = append(parts, fmt.Sprintf("%v=%v", breakpointBounds[i], bound))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "always"
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Runtime is our breakpoint without any overrides.
+func (b *SceneBreakpoint) Runtime() components.Breakpoint {
+	return components.Breakpoint{
+		MinWidth:  b.MinWidth,
+		MaxWidth:  b.MaxWidth,
+		MinHeight: b.MinHeight,
+		MaxHeight: b.MaxHeight,
+	}
+}
+
+// BuildBreakpoints creates breakpoints overriding the editor visuals of our scene,
+// base values are the values saved in each visual. Overrides of missing visuals
+// are skipped so renaming a visual does not stop our scene from loading.
+func (s *SceneData) BuildBreakpoints(visuals []*SceneVisual) (components.Breakpoints, error) {
+	breakpoints := make(components.Breakpoints, 0, len(s.Breakpoints))
+	for _, b := range s.Breakpoints {
+		breakpoint := b.Runtime()
+		for _, o := range b.Overrides {
+			visual := FindVisual(visuals, o.Visual)
+			if visual == nil || visual.Visual == nil {
+				continue
+			}
+
+			base, err := visual.PropertyValue(o.Property)
+			if err != nil {
+				return nil, err
+			}
+
+			breakpoint.Overrides = append(breakpoint.Overrides, components.Override{
+				Set:   components.TrackSetter(visual.Visual, o.Property),
+				Base:  base,
+				Value: o.Value,
+			})
+		}
+
+		breakpoints = append(breakpoints, breakpoint)
+	}
+
+	return breakpoints, nil
+}
+
+// ApplyBreakpoints overrides our editor visuals for the size our editor previews.
+func ApplyBreakpoints(editor Editor) error {
+	breakpoints, err := editor.SceneData().BuildBreakpoints(editor.SceneData().Visuals)
+	if err != nil {
+		return err
+	}
+
+	breakpoints.Apply(editor.PreviewSize())
+	return nil
+}
+
+// resetOverride sets a property back to the value saved in its visual,
+// once an override is removed applying breakpoints no longer resets it.
+func resetOverride(sceneData *SceneData, name string, property components.TimelineProperty) error {
+	visual := FindVisual(sceneData.Visuals, name)
+	if visual == nil || visual.Visual == nil {
+		return nil
+	}
+
+	value, err := visual.PropertyValue(property)
+	if err != nil {
+		return err
+	}
+
+	components.TrackSetter(visual.Visual, property)(value)
+	return nil
+}
+
+func breakpointSuggestions(editor Editor, partial string) []string {
+	names := make([]string, 0, len(editor.SceneData().Breakpoints))
+	for _, b := range editor.SceneData().Breakpoints {
+		names = append(names, b.Name)
+	}
+
+	return Filter(partial, names, StringUnchanged)
+}
+
+func breakpointCommand() *Command {
+	return &Command{
+		Key: "breakpoint",
+		Help: func() string {
+			return "list, add or remove breakpoints overriding our visuals by window size"
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			width, height := editor.PreviewSize()
+
+			var b strings.Builder
+			for _, bp := range editor.SceneData().Breakpoints {
+				marker := ""
+				if bp.Runtime().Matches(width, height) {
+					marker = " *"
+				}
+
+				WriteFormat(&b, "%v: %v, %v overrides%v", bp.Name, bp.Bounds(), len(bp.Overrides), marker)
+			}
+
+			return b.String(), nil
+		},
+		Subcommands: []*Command{
+			{
+				Key: "add",
+				Help: func() string {
+					return "add a breakpoint: add <name> <minWidth> <maxWidth> [minHeight] [maxHeight], 0 is unbounded"
+				},
+				Validations: []Validation{
+					MinArgs(3),
+					ArgFloat(1),
+					ArgFloat(2),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					if _, err := editor.SceneData().Breakpoint(args[0]); err == nil {
+						return "", fmt.Errorf("breakpoint %v already exists", args[0])
+					}
+
+					bounds := make([]float64, len(breakpointBounds))
+					for i, arg := range args[1:] {
+						if i >= len(bounds) {
+							break
+						}
+
+						bound, err := strconv.ParseFloat(arg, 64)
+						if err != nil {
+							return "", fmt.Errorf("%w: %v is not a float64", errInvalidArg, arg)
+						}
+
+						bounds[i] = bound
+					}
+
+					editor.SceneData().Breakpoints = append(editor.SceneData().Breakpoints, &SceneBreakpoint{
+						Name:      args[0],
+						MinWidth:  bounds[0],
+						MaxWidth:  bounds[1],
+						MinHeight: bounds[2],
+						MaxHeight: bounds[3],
+					})
+
+					return "", nil
+				},
+			},
+			{
+				Key: "remove",
+				Help: func() string {
+					return "remove a breakpoint and its overrides"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 1 {
+						return nil
+					}
+
+					return breakpointSuggestions(editor, partial[0])
+				},
+				Validations: []Validation{
+					RequiredArgs(1),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					sceneData := editor.SceneData()
+					breakpoint, err := sceneData.Breakpoint(args[0])
+					if err != nil {
+						return "", err
+					}
+
+					for _, o := range breakpoint.Overrides {
+						if err := resetOverride(sceneData, o.Visual, o.Property); err != nil {
+							return "", err
+						}
+					}
+
+					kept := sceneData.Breakpoints[:0]
+					for _, b := range sceneData.Breakpoints {
+						if b.Name != args[0] {
+							kept = append(kept, b)
+						}
+					}
+
+					sceneData.Breakpoints = kept
+					return "", ApplyBreakpoints(editor)
+				},
+			},
+			{
+				Key: "override",
+				Help: func() string {
+					return "override a property of our visual: override <breakpoint> <property> [value], defaults to its value now"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					switch len(partial) {
+					case 1:
+						return breakpointSuggestions(editor, partial[0])
+					case 2:
+						return Filter(partial[1], timelinePropertyOptions(), StringUnchanged)
+					default:
+						return nil
+					}
+				},
+				Validations: []Validation{
+					RequiresVisual(),
+					MinArgs(2),
+					ArgsIn(1, timelinePropertyOptions()),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					breakpoint, err := editor.SceneData().Breakpoint(args[0])
+					if err != nil {
+						return "", err
+					}
+
+					property := components.TimelineProperty(args[1])
+					value, err := editor.Visual().PropertyValue(property)
+					if err != nil {
+						return "", err
+					}
+
+					if len(args) > 2 {
+						value, err = strconv.ParseFloat(args[2], 64)
+						if err != nil {
+							return "", fmt.Errorf("%w: %v is not a float64", errInvalidArg, args[2])
+						}
+					}
+
+					breakpoint.SetOverride(editor.Visual().Name, property, value)
+					return "", ApplyBreakpoints(editor)
+				},
+			},
+			{
+				Key: "clear",
+				Help: func() string {
+					return "remove the override of a property of our visual: clear <breakpoint> <property>"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					switch len(partial) {
+					case 1:
+						return breakpointSuggestions(editor, partial[0])
+					case 2:
+						return Filter(partial[1], timelinePropertyOptions(), StringUnchanged)
+					default:
+						return nil
+					}
+				},
+				Validations: []Validation{
+					RequiresVisual(),
+					RequiredArgs(2),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					breakpoint, err := editor.SceneData().Breakpoint(args[0])
+					if err != nil {
+						return "", err
+					}
+
+					property := components.TimelineProperty(args[1])
+					breakpoint.RemoveOverride(editor.Visual().Name, property)
+					if err := resetOverride(editor.SceneData(), editor.Visual().Name, property); err != nil {
+						return "", err
+					}
+
+					return "", ApplyBreakpoints(editor)
+				},
+			},
+		},
+	}
+}
+
+func previewSizeCommand() *Command {
+	return &Command{
+		Key: "preview-size",
+		Help: func() string {
+			return "preview our scene at a window size: preview-size <width> <height>, or reset to the editor window"
+		},
+		Suggestions: func(editor Editor, partial []string) []string {
+			if len(partial) != 1 {
+				return nil
+			}
+
+			return Filter(partial[0], []string{"reset"}, StringUnchanged)
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			switch len(args) {
+			case 0:
+				width, height := editor.PreviewSize()
+				return fmt.Sprintf("%vx%v", width, height), nil
+			case 1:
+				if args[0] != "reset" {
+					return "", fmt.Errorf("%w: %v", errInvalidArg, args[0])
+				}
+
+				editor.SetPreviewSize(0, 0)
+			default:
+				width, err := strconv.ParseFloat(args[0], 64)
+				if err != nil || width <= 0 {
+					return "", fmt.Errorf("%w: width %v", errInvalidArg, args[0])
+				}
+
+				height, err := strconv.ParseFloat(args[1], 64)
+				if err != nil || height <= 0 {
+					return "", fmt.Errorf("%w: height %v", errInvalidArg, args[1])
+				}
+
+				editor.SetPreviewSize(width, height)
+			}
+
+			return "", ApplyBreakpoints(editor)
+		},
+	}
+}
+
+// validateBreakpoints checks our breakpoints can be generated
+// and only override visuals and properties that exist.
+func validateBreakpoints(file string, scene *SceneData, visuals map[string]string) []Issue {
+	var issues []Issue
+
+	names := make(map[string]struct{})
+	for _, b := range scene.Breakpoints {
+		addIssue := func(format string, args ...any) {
+			issues = append(issues, Issue{
+				File:    file,
+				Path:    "breakpoints/" + b.Name,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		if _, found := names[b.Name]; found {
+			addIssue("duplicate breakpoint name")
+		}
+		names[b.Name] = struct{}{}
+
+		if b.MaxWidth != 0 && b.MaxWidth <= b.MinWidth {
+			addIssue("max width %v is not above min width %v", b.MaxWidth, b.MinWidth)
+		}
+
+		if b.MaxHeight != 0 && b.MaxHeight <= b.MinHeight {
+			addIssue("max height %v is not above min height %v", b.MaxHeight, b.MinHeight)
+		}
+
+		for _, o := range b.Overrides {
+			if _, found := visuals[o.Visual]; !found {
+				addIssue("visual %v not found", o.Visual)
+				continue
+			}
+
+			if !contains(timelinePropertyOptions(), string(o.Property)) {
+				addIssue("unknown property %v of %v", o.Property, o.Visual)
+				continue
+			}
+
+			visual := FindVisual(scene.Visuals, o.Visual)
+			if visual != nil && visual.UseWindowSize &&
+				(o.Property == components.PropertyWidth || o.Property == components.PropertyHeight) {
+				addIssue("%v uses the window size, its %v can not be overridden", o.Visual, o.Property)
+			}
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"io"
+	"strings"
+
+	"github.com/miniscruff/igloo"
+	"github.com/miniscruff/igloo/content"
+	"github.com/miniscruff/inuit/components"
+)
+
+// ButtonVisualData is kept in the data field of button visuals,
+// over and clicked are optional and a label is only added with a font.
+type ButtonVisualData struct {
+	Normal  string `json:"normal"`
+	Over    string `json:"over,omitempty"`
+	Clicked string `json:"clicked,omitempty"`
+	Font    string `json:"font,omitempty"`
+	Text    string `json:"text,omitempty"`
+}
+
+// Sprites are the sprite content keys of each state.
+func (d ButtonVisualData) Sprites() map[components.ButtonState]string {
+	return map[components.ButtonState]string{
+		components.ButtonNormal:  d.Normal,
+		components.ButtonOver:    d.Over,
+		components.ButtonClicked: d.Clicked,
+	}
+}
+
+func buttonStateOptions() []string {
+	options := make([]string, 0, len(components.ButtonStates))
+	for _, state := range components.ButtonStates {
+		options = append(options, string(state))
+	}
+
+	return options
+}
+
+func buttonVisualKind() *VisualKind {
+	return &VisualKind{
+		Type: ButtonVisualType,
+		NewData: func() any {
+			return &ButtonVisualData{}
+		},
+		Content: func(visual *SceneVisual) ([]ContentRef, error) {
+			var data ButtonVisualData
+			if err := visual.DecodeData(&data); err != nil {
+				return nil, err
+			}
+
+			refs := []ContentRef{{Key: data.Normal, Type: ContentSprite}}
+			for _, key := range []string{data.Over, data.Clicked} {
+				if key != "" {
+					refs = append(refs, ContentRef{Key: key, Type: ContentSprite})
+				}
+			}
+
+			if data.Font != "" {
+				refs = append(refs, ContentRef{Key: data.Font, Type: ContentFont})
+			}
+
+			return refs, nil
+		},
+		New: func(visual *SceneVisual, contentMap map[string]any) (any, *igloo.Visualer, error) {
+			var data ButtonVisualData
+			if err := visual.DecodeData(&data); err != nil {
+				return nil, nil, err
+			}
+
+			buttonVis := components.NewButtonVisual()
+			for _, state := range components.ButtonStates {
+				key := data.Sprites()[state]
+				if key == "" && state != components.ButtonNormal {
+					continue
+				}
+
+				sprite, err := LookupContent[*content.Sprite](contentMap, key)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				buttonVis.SetStateSprite(state, sprite)
+			}
+
+			if data.Font != "" {
+				font, err := LookupContent[*content.Font](contentMap, data.Font)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				buttonVis.SetLabel(font, data.Text)
+			}
+
+			return buttonVis, buttonVis.Visualer, nil
+		},
+		GoType:  "*components.ButtonVisual",
+		Imports: []string{"github.com/miniscruff/inuit/components"},
+		Generate: func(w io.StringWriter, name string, visual *SceneVisual) error {
+			var data ButtonVisualData
+			if err := visual.DecodeData(&data); err != nil {
+				return err
+			}
+
+			WriteFormat(w, "%v := components.NewButtonVisual()", name)
+			WriteFormat(w, "%v.SetStateSprite(components.ButtonNormal, content.%v)", name, data.Normal)
+			if data.Over != "" {
+				WriteFormat(w, "%v.SetStateSprite(components.ButtonOver, content.%v)", name, data.Over)
+			}
+			if data.Clicked != "" {
+				WriteFormat(w, "%v.SetStateSprite(components.ButtonClicked, content.%v)", name, data.Clicked)
+			}
+			if data.Font != "" {
+				WriteFormat(w, "%v.SetLabel(content.%v, %q)", name, data.Font, data.Text)
+			}
+			return nil
+		},
+		Updates: true,
+		Commands: func() []*Command {
+			return []*Command{
+				{
+					Key: "sprite",
+					Help: func() string {
+						return "change the sprite content of a button state: sprite <state> <content|none>"
+					},
+					Suggestions: func(editor Editor, partial []string) []string {
+						switch len(partial) {
+						case 1:
+							return Filter(partial[0], buttonStateOptions(), StringUnchanged)
+						case 2:
+							return ContentSuggestions(ContentSprite)(editor, partial[1:])
+						default:
+							return nil
+						}
+					},
+					Validations: []Validation{
+						RequiresVisualType(ButtonVisualType),
+						RequiredArgs(2),
+						ArgsIn(0, buttonStateOptions()),
+					},
+					Mutates: true,
+					Run: func(editor Editor, args []string) (string, error) {
+						var data ButtonVisualData
+						if err := editor.Visual().DecodeData(&data); err != nil {
+							return "", err
+						}
+
+						state := components.ButtonState(args[0])
+						key := args[1]
+
+						var sprite *content.Sprite
+						if key == "none" && state != components.ButtonNormal {
+							key = ""
+						} else {
+							var err error
+							sprite, err = LookupContent[*content.Sprite](editor.Content(), key)
+							if err != nil {
+								return "", err
+							}
+						}
+
+						switch state {
+						case components.ButtonNormal:
+							data.Normal = key
+						case components.ButtonOver:
+							data.Over = key
+						case components.ButtonClicked:
+							data.Clicked = key
+						}
+
+						if err := editor.Visual().EncodeData(data); err != nil {
+							return "", err
+						}
+
+						editor.Visual().Instance.(*components.ButtonVisual).SetStateSprite(state, sprite)
+						return "", nil
+					},
+				},
+				{
+					Key: "label",
+					Help: func() string {
+						return "add a label to our button: label <font> [text]"
+					},
+					Suggestions: ContentSuggestions(ContentFont),
+					Validations: []Validation{
+						RequiresVisualType(ButtonVisualType),
+						MinArgs(1),
+					},
+					Mutates: true,
+					Run: func(editor Editor, args []string) (string, error) {
+						font, err := LookupContent[*content.Font](editor.Content(), args[0])
+						if err != nil {
+							return "", err
+						}
+
+						var data ButtonVisualData
+						if err := editor.Visual().DecodeData(&data); err != nil {
+							return "", err
+						}
+
+						data.Font = args[0]
+						data.Text = strings.Join(args[1:], " ")
+						if err := editor.Visual().EncodeData(data); err != nil {
+							return "", err
+						}
+
+						editor.Visual().Instance.(*components.ButtonVisual).SetLabel(font, data.Text)
+						return "", nil
+					},
+				},
+			}
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var errInvalidColor = errors.New("color must be #RRGGBB or #RRGGBBAA")
+
+// ParseColor reads a hex color as #RRGGBB or #RRGGBBAA.
+func ParseColor(hex string) (color.NRGBA, error) {
+	digits := strings.TrimPrefix(hex, "#")
+	if len(digits) == 6 {
+		digits += "ff"
+	}
+
+	if len(digits) != 8 || digits == hex {
+		return color.NRGBA{}, fmt.Errorf("%w: %v", errInvalidColor, hex)
+	}
+
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, fmt.Errorf("%w: %v", errInvalidColor, hex)
+	}
+
+	return color.NRGBA{
+		R: uint8(value >> 24),
+		G: uint8(value >> 16),
+		B: uint8(value >> 8),
+		A: uint8(value),
+	}, nil
+}
+
+// FormatColor writes a color as #RRGGBBAA.
+func FormatColor(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+// HasTint is whether our visual changes the color or alpha it is drawn with.
+func (v *SceneVisual) HasTint() bool {
+	return v.Color != "" || v.Alpha != nil
+}
+
+// Tint returns the color and alpha of our visual,
+// defaulting to white and fully opaque.
+func (v *SceneVisual) Tint() (color.NRGBA, float64, error) {
+	tint := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	alpha := 1.0
+
+	if v.Color != "" {
+		var err error
+		tint, err = ParseColor(v.Color)
+		if err != nil {
+			return tint, alpha, err
+		}
+	}
+
+	if v.Alpha != nil {
+		alpha = *v.Alpha
+	}
+
+	return tint, alpha, nil
+}
+
+// ApplyTint resets a color matrix to the tint of our visual.
+func (v *SceneVisual) ApplyTint(colorM *ebiten.ColorM) error {
+	tint, alpha, err := v.Tint()
+	if err != nil {
+		return err
+	}
+
+	colorM.Reset()
+	colorM.ScaleWithColor(tint)
+	colorM.Scale(1, 1, 1, alpha)
+
+	return nil
+}
@@ -2,11 +2,15 @@ package commands
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+
+	"github.com/miniscruff/inuit/components"
 )
 
 var (
-	errCommandNotFound = errors.New("command not found")
+	errCommandNotFound    = errors.New("command not found")
+	errSubcommandRequired = errors.New("subcommand required")
 )
 
 type CommandAction func(editor Editor, args []string) (string, error)
@@ -15,9 +19,29 @@ type Validation func(editor Editor, args []string) error
 type Editor interface {
 	Visual() *SceneVisual
 	SetVisual(visual *SceneVisual)
+	SetTimeline(timeline *components.Timeline)
+	Strings() StringTables
+	Locale() string
+	SetLocale(locale string)
+	// PreviewSize is the window size our breakpoints are applied for
+	PreviewSize() (width, height float64)
+	// SetPreviewSize previews a window size, zero previews the editor window
+	SetPreviewSize(width, height float64)
 	SceneData() *SceneData
+	Content() map[string]any
+	ContentType(key string) ContentType
+	Metadata() *Metadata
 	Path() string
+	SceneFile() SceneFileInfo
+	SetSceneFile(info SceneFileInfo)
 	Commands() *Commands
+	Dirty() bool
+	SetDirty(dirty bool)
+	Quit()
+	Reload() error
+	// ReloadContent reloads our assets and content after a command writes them,
+	// without it our own writes are seen as outside changes to our project
+	ReloadContent() error
 }
 
 type Command struct {
@@ -27,6 +51,8 @@ type Command struct {
 	Validations []Validation
 	Run         CommandAction
 	Subcommands []*Command
+	// Mutates marks the scene dirty after a successful run
+	Mutates bool
 }
 
 type Commands struct {
@@ -48,13 +74,23 @@ func (c *Commands) Run(text string) (string, error) {
 		return "", errCommandNotFound
 	}
 
+	// groups such as set only run their subcommands
+	if cmd.Run == nil {
+		return "", fmt.Errorf("%w, one of:\n%v", errSubcommandRequired, commandsHelp(cmd.Subcommands))
+	}
+
 	for _, v := range cmd.Validations {
 		if err := v(c.editor, args); err != nil {
 			return "", err
 		}
 	}
 
-	return cmd.Run(c.editor, args)
+	output, err := cmd.Run(c.editor, args)
+	if err == nil && cmd.Mutates {
+		c.editor.SetDirty(true)
+	}
+
+	return output, err
 }
 
 func (c *Commands) BuildSuggestions(text string) []string {
@@ -91,10 +127,27 @@ func (c *Commands) BuildSuggestions(text string) []string {
 
 func buildCommands() []*Command {
 	return []*Command{
+		assetCommand(),
+		breakpointCommand(),
 		cdCommand(),
+		contentCommand(),
+		diffCommand(),
+		eventCommand(),
 		helpCommand(),
+		lintCommand(),
+		localeCommand(),
 		lsCommand(),
+		pauseCommand(),
+		playCommand(),
+		previewSizeCommand(),
+		propCommand(),
+		quitCommand(),
+		reloadCommand(),
+		restoreCommand(),
 		setCommand(),
+		soundCommand(),
+		tagCommand(),
+		timelineCommand(),
 		writeCommand(),
 	}
 }
@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/miniscruff/igloo"
+	"github.com/miniscruff/igloo/mathf"
+	"github.com/miniscruff/inuit/components"
+)
+
+// ContainerVisualData is kept in the data field of HBox, VBox and Grid visuals,
+// columns are only used by grids.
+type ContainerVisualData struct {
+	Spacing float64                `json:"spacing,omitempty"`
+	Padding mathf.Sides            `json:"padding"`
+	Align   components.LayoutAlign `json:"align,omitempty"`
+	Columns int                    `json:"columns,omitempty"`
+}
+
+// Apply sets the layout of a container to our data.
+func (d ContainerVisualData) Apply(container *components.ContainerVisual) {
+	container.Spacing = d.Spacing
+	container.Padding = d.Padding
+	container.Align = d.Align
+	if container.Align == "" {
+		container.Align = components.AlignStart
+	}
+
+	container.Columns = d.Columns
+	container.Invalidate()
+}
+
+// layoutNames are the generated names of our layout constants.
+var layoutNames = map[string]string{
+	string(components.LayoutHorizontal): "components.LayoutHorizontal",
+	string(components.LayoutVertical):   "components.LayoutVertical",
+	string(components.LayoutGrid):       "components.LayoutGrid",
+	string(components.AlignStart):       "components.AlignStart",
+	string(components.AlignCenter):      "components.AlignCenter",
+	string(components.AlignEnd):         "components.AlignEnd",
+}
+
+func layoutAlignOptions() []string {
+	options := make([]string, 0, len(components.LayoutAligns))
+	for _, align := range components.LayoutAligns {
+		options = append(options, string(align))
+	}
+
+	return options
+}
+
+func containerVisualKind(visualType VisualType, direction components.LayoutDirection) *VisualKind {
+	return &VisualKind{
+		Type: visualType,
+		NewData: func() any {
+			return &ContainerVisualData{}
+		},
+		Content: func(visual *SceneVisual) ([]ContentRef, error) {
+			return nil, nil
+		},
+		New: func(visual *SceneVisual, contentMap map[string]any) (any, *igloo.Visualer, error) {
+			var data ContainerVisualData
+			if err := visual.DecodeData(&data); err != nil {
+				return nil, nil, err
+			}
+
+			containerVis := components.NewContainerVisual(direction)
+			data.Apply(containerVis)
+			return containerVis, containerVis.Visualer, nil
+		},
+		GoType:  "*components.ContainerVisual",
+		Imports: []string{"github.com/miniscruff/inuit/components"},
+		Generate: func(w io.StringWriter, name string, visual *SceneVisual) error {
+			var data ContainerVisualData
+			if err := visual.DecodeData(&data); err != nil {
+				return err
+			}
+
+			WriteFormat(w, "%v := components.NewContainerVisual(%v)", name, layoutNames[string(direction)])
+			if data.Spacing != 0 {
+				WriteFormat(w, "%v.Spacing = %v", name, data.Spacing)
+			}
+			if data.Padding != mathf.SidesZero {
+				WriteFormat(w,
+					"%v.Padding = mathf.Sides{Left: %v, Right: %v, Top: %v, Bottom: %v}",
+					name, data.Padding.Left, data.Padding.Right, data.Padding.Top, data.Padding.Bottom,
+				)
+			}
+			if data.Align != "" && data.Align != components.AlignStart {
+				WriteFormat(w, "%v.Align = %v", name, layoutNames[string(data.Align)])
+			}
+			if data.Columns > 1 {
+				WriteFormat(w, "%v.Columns = %v", name, data.Columns)
+			}
+			return nil
+		},
+		Updates:   true,
+		Container: true,
+		Commands: func() []*Command {
+			align := containerCommand(visualType,
+				"align",
+				"change how children are aligned: align <start|center|end>",
+				[]Validation{RequiredArgs(1), ArgsIn(0, layoutAlignOptions())},
+				func(data *ContainerVisualData, args []string) {
+					data.Align = components.LayoutAlign(args[0])
+				},
+			)
+			align.Suggestions = func(editor Editor, partial []string) []string {
+				if len(partial) != 1 {
+					return nil
+				}
+
+				return Filter(partial[0], layoutAlignOptions(), StringUnchanged)
+			}
+
+			commands := []*Command{
+				containerCommand(visualType,
+					"spacing",
+					"change the space between children: spacing <amount>",
+					[]Validation{RequiredArgs(1), ArgFloat(0)},
+					func(data *ContainerVisualData, args []string) {
+						data.Spacing, _ = strconv.ParseFloat(args[0], 64)
+					},
+				),
+				containerCommand(visualType,
+					"padding",
+					"change the space around children: padding <left> <right> <top> <bottom>",
+					[]Validation{RequiredArgs(4), ArgFloat(0), ArgFloat(1), ArgFloat(2), ArgFloat(3)},
+					func(data *ContainerVisualData, args []string) {
+						data.Padding.Left, _ = strconv.ParseFloat(args[0], 64)
+						data.Padding.Right, _ = strconv.ParseFloat(args[1], 64)
+						data.Padding.Top, _ = strconv.ParseFloat(args[2], 64)
+						data.Padding.Bottom, _ = strconv.ParseFloat(args[3], 64)
+					},
+				),
+				align,
+			}
+
+			if direction == components.LayoutGrid {
+				commands = append(commands, containerCommand(visualType,
+					"columns",
+					"change the number of grid columns: columns <count>",
+					[]Validation{RequiredArgs(1), ArgInt(0)},
+					func(data *ContainerVisualData, args []string) {
+						data.Columns, _ = strconv.Atoi(args[0])
+					},
+				))
+			}
+
+			return commands
+		},
+	}
+}
+
+// containerCommand builds a command updating the data and layout of our container.
+func containerCommand(
+	visualType VisualType,
+	key, help string,
+	validations []Validation,
+	update func(data *ContainerVisualData, args []string),
+) *Command {
+	return &Command{
+		Key: key,
+		Help: func() string {
+			return help
+		},
+		Validations: append([]Validation{RequiresVisualType(visualType)}, validations...),
+		Mutates:     true,
+		Run: func(editor Editor, args []string) (string, error) {
+			var data ContainerVisualData
+			if err := editor.Visual().DecodeData(&data); err != nil {
+				return "", err
+			}
+
+			update(&data, args)
+			if err := editor.Visual().EncodeData(data); err != nil {
+				return "", err
+			}
+
+			data.Apply(editor.Visual().Instance.(*components.ContainerVisual))
+			return "", nil
+		},
+	}
+}
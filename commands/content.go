@@ -0,0 +1,316 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/miniscruff/inuit/components"
+)
+
+func contentCommand() *Command {
+	return &Command{
+		Key: "content",
+		Help: func() string {
+			return "list or change the content of our scene"
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			var content map[string]Content
+			if err := LoadContent(&content); err != nil {
+				return "", err
+			}
+
+			var b strings.Builder
+			for _, key := range editor.SceneData().Content {
+				c := content[key]
+				WriteFormat(&b, "%v: %v%v", key, c.Type, regionText(c))
+			}
+
+			return b.String(), nil
+		},
+		Subcommands: []*Command{
+			{
+				Key: "add",
+				Help: func() string {
+					return "add content to our scene"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) == 0 {
+						return nil
+					}
+
+					keys := make([]string, 0, len(editor.Content()))
+					for key := range editor.Content() {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+
+					return Filter(partial[len(partial)-1], keys, StringUnchanged)
+				},
+				Validations: []Validation{
+					MinArgs(1),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					sceneData := editor.SceneData()
+					for _, key := range args {
+						if _, found := editor.Content()[key]; !found {
+							return "", fmt.Errorf("%w: %v", errContentNotFound, key)
+						}
+
+						if !contains(sceneData.Content, key) {
+							sceneData.Content = append(sceneData.Content, key)
+						}
+					}
+
+					return "", nil
+				},
+			},
+			{
+				Key: "remove",
+				Help: func() string {
+					return "remove content from our scene"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) == 0 {
+						return nil
+					}
+
+					return Filter(partial[len(partial)-1], editor.SceneData().Content, StringUnchanged)
+				},
+				Validations: []Validation{
+					MinArgs(1),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					sceneData := editor.SceneData()
+					kept := sceneData.Content[:0]
+					for _, key := range sceneData.Content {
+						if !contains(args, key) {
+							kept = append(kept, key)
+						}
+					}
+
+					sceneData.Content = kept
+					return "", nil
+				},
+			},
+			{
+				Key: "import",
+				Help: func() string {
+					return "import a sprite sheet json from our assets: import <sheet> [prefix]"
+				},
+				Validations: []Validation{
+					MinArgs(1),
+				},
+				Run: func(editor Editor, args []string) (string, error) {
+					prefix := ""
+					if len(args) > 1 {
+						prefix = args[1]
+					}
+
+					keys, err := ImportSpriteSheet(*editor.Metadata(), args[0], prefix)
+					if err != nil {
+						return "", err
+					}
+
+					if err := editor.ReloadContent(); err != nil {
+						return "", err
+					}
+
+					return fmt.Sprintf("imported %v sprites, use content add to use them:\n%v",
+						len(keys),
+						strings.Join(keys, " "),
+					), nil
+				},
+			},
+			{
+				Key: "animate",
+				Help: func() string {
+					return "create animation content from sprites: animate <key> <seconds> <sprite...>"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) < 3 {
+						return nil
+					}
+
+					return ContentSuggestions(ContentSprite)(editor, partial[len(partial)-1:])
+				},
+				Validations: []Validation{
+					MinArgs(3),
+					ArgFloat(1),
+				},
+				Run: func(editor Editor, args []string) (string, error) {
+					var content map[string]Content
+					if err := LoadContent(&content); err != nil {
+						return "", err
+					}
+
+					if existing, found := content[args[0]]; found && existing.Type != ContentAnimation {
+						return "", fmt.Errorf("%w: %v is %v content", errKeyInUse, args[0], existing.Type)
+					}
+
+					duration, _ := strconv.ParseFloat(args[1], 64)
+					animation := content[args[0]].Animation
+					animation.Frames = nil
+
+					for _, key := range args[2:] {
+						c, found := content[key]
+						if !found || c.Type != ContentSprite {
+							return "", fmt.Errorf("%w: %v", errContentNotFound, key)
+						}
+
+						animation.Frames = append(animation.Frames, AnimationFrameContent{
+							BaseContent: c.Sprite.BaseContent,
+							Region:      c.Sprite.Region,
+							Duration:    duration,
+						})
+					}
+
+					content[args[0]] = Content{
+						Type:      ContentAnimation,
+						Animation: animation,
+					}
+
+					if err := SaveContent(content); err != nil {
+						return "", err
+					}
+
+					return "", editor.ReloadContent()
+				},
+			},
+			{
+				Key: "loop",
+				Help: func() string {
+					return "set how animation content loops: loop <key> <mode>"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					switch len(partial) {
+					case 1:
+						return ContentSuggestions(ContentAnimation)(editor, partial)
+					case 2:
+						return Filter(partial[1], loopModeOptions(), StringUnchanged)
+					default:
+						return nil
+					}
+				},
+				Validations: []Validation{
+					RequiredArgs(2),
+					ArgsIn(1, loopModeOptions()),
+				},
+				Run: func(editor Editor, args []string) (string, error) {
+					var content map[string]Content
+					if err := LoadContent(&content); err != nil {
+						return "", err
+					}
+
+					c, found := content[args[0]]
+					if !found || c.Type != ContentAnimation {
+						return "", fmt.Errorf("%w: %v", errContentNotFound, args[0])
+					}
+
+					c.Animation.Loop = components.LoopMode(args[1])
+					content[args[0]] = c
+					if err := SaveContent(content); err != nil {
+						return "", err
+					}
+
+					return "", editor.ReloadContent()
+				},
+			},
+			{
+				Key: "region",
+				Help: func() string {
+					return "set the region of sprite content: region <key> <x> <y> <width> <height>"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 1 {
+						return nil
+					}
+
+					return ContentSuggestions(ContentSprite)(editor, partial)
+				},
+				Validations: []Validation{
+					RequiredArgs(5),
+					ArgFloat(1),
+					ArgFloat(2),
+					ArgFloat(3),
+					ArgFloat(4),
+				},
+				Run: func(editor Editor, args []string) (string, error) {
+					var content map[string]Content
+					if err := LoadContent(&content); err != nil {
+						return "", err
+					}
+
+					c, found := content[args[0]]
+					if !found {
+						return "", fmt.Errorf("%w: %v", errContentNotFound, args[0])
+					}
+
+					var values []int
+					for _, arg := range args[1:] {
+						value, _ := strconv.ParseFloat(arg, 64)
+						values = append(values, int(value))
+					}
+
+					region := &Region{
+						X:      values[0],
+						Y:      values[1],
+						Width:  values[2],
+						Height: values[3],
+					}
+
+					switch c.Type {
+					case ContentSprite:
+						c.Sprite.Region = region
+					case ContentSliced:
+						c.Sliced.Region = region
+					default:
+						return "", fmt.Errorf("%w: %v content has no region", errInvalidArg, c.Type)
+					}
+
+					content[args[0]] = c
+					if err := SaveContent(content); err != nil {
+						return "", err
+					}
+
+					return "", editor.ReloadContent()
+				},
+			},
+		},
+	}
+}
+
+func loopModeOptions() []string {
+	options := make([]string, 0, len(components.LoopModes))
+	for _, mode := range components.LoopModes {
+		options = append(options, string(mode))
+	}
+
+	return options
+}
+
+func regionText(c Content) string {
+	region := c.Sprite.Region
+	if c.Type == ContentSliced {
+		region = c.Sliced.Region
+	}
+
+	if region == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(" %v,%v %vx%v", region.X, region.Y, region.Width, region.Height)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+func diffCommand() *Command {
+	return &Command{
+		Key: "diff",
+		Help: func() string {
+			return "list what write would change in the scene on disk"
+		},
+		Run: diffAction,
+	}
+}
+
+func diffAction(editor Editor, args []string) (string, error) {
+	var diskData SceneData
+	if err := LoadSceneData(&diskData, editor.Path()); err != nil {
+		return "", err
+	}
+
+	lines, err := DiffSceneData(&diskData, editor.SceneData())
+	if err != nil {
+		return "", err
+	}
+
+	if len(lines) == 0 {
+		return "no changes", nil
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// DiffSceneData lists the changes needed to turn one scene into another,
+// one change per line. Lists of named objects such as visuals are matched
+// by name instead of index so a rename or insert does not show every sibling.
+func DiffSceneData(from, to *SceneData) ([]string, error) {
+	fromValue, err := toJSONValue(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toValue, err := toJSONValue(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	diffValues("", fromValue, toValue, &lines)
+	return lines, nil
+}
+
+func toJSONValue(data any) (any, error) {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	err = json.Unmarshal(dataBytes, &value)
+	return value, err
+}
+
+func diffValues(path string, from, to any, lines *[]string) {
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+
+	switch fromValue := from.(type) {
+	case map[string]any:
+		if toValue, ok := to.(map[string]any); ok {
+			diffObjects(path, fromValue, toValue, lines)
+			return
+		}
+	case []any:
+		if toValue, ok := to.([]any); ok {
+			diffLists(path, fromValue, toValue, lines)
+			return
+		}
+	}
+
+	switch {
+	case from == nil:
+		*lines = append(*lines, fmt.Sprintf("+ %v: %v", path, formatJSONValue(to)))
+	case to == nil:
+		*lines = append(*lines, fmt.Sprintf("- %v: %v", path, formatJSONValue(from)))
+	default:
+		*lines = append(*lines, fmt.Sprintf(
+			"~ %v: %v -> %v",
+			path,
+			formatJSONValue(from),
+			formatJSONValue(to),
+		))
+	}
+}
+
+func diffObjects(path string, from, to map[string]any, lines *[]string) {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, found := from[k]; !found {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		diffValues(joinPath(path, k), from[k], to[k], lines)
+	}
+}
+
+func diffLists(path string, from, to []any, lines *[]string) {
+	fromNames, fromNamed := namedItems(from)
+	toNames, toNamed := namedItems(to)
+
+	if !fromNamed || !toNamed {
+		for i := 0; i < len(from) || i < len(to); i++ {
+			var fromItem, toItem any
+			if i < len(from) {
+				fromItem = from[i]
+			}
+			if i < len(to) {
+				toItem = to[i]
+			}
+
+			diffValues(fmt.Sprintf("%v[%v]", path, i), fromItem, toItem, lines)
+		}
+
+		return
+	}
+
+	for _, name := range fromNames {
+		diffValues(fmt.Sprintf("%v[%v]", path, name), findNamed(from, name), findNamed(to, name), lines)
+	}
+
+	for _, name := range toNames {
+		if findNamed(from, name) == nil {
+			diffValues(fmt.Sprintf("%v[%v]", path, name), nil, findNamed(to, name), lines)
+		}
+	}
+}
+
+// namedItems returns the names of every item if all items are objects
+// with a unique name.
+func namedItems(items []any) ([]string, bool) {
+	names := make([]string, 0, len(items))
+	seen := make(map[string]struct{})
+
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		name, ok := obj["name"].(string)
+		if !ok {
+			return nil, false
+		}
+
+		if _, found := seen[name]; found {
+			return nil, false
+		}
+
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
+	return names, true
+}
+
+func findNamed(items []any, name string) any {
+	for _, item := range items {
+		if item.(map[string]any)["name"] == name {
+			return item
+		}
+	}
+
+	return nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func formatJSONValue(value any) string {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return strings.TrimSpace(string(valueBytes))
+}
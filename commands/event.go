@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"strings"
+)
+
+var errUnknownEvent = errors.New("unknown event")
+
+const (
+	EventClick = "onClick"
+	EventEnter = "onEnter"
+	EventLeave = "onLeave"
+)
+
+var eventNames = []string{EventClick, EventEnter, EventLeave}
+
+// sceneMethods are already defined by scenes, handlers can not reuse them.
+var sceneMethods = map[string]struct{}{
+	"Dispose":   {},
+	"Draw":      {},
+	"OnResize":  {},
+	"PostSetup": {},
+	"Setup":     {},
+	"Update":    {},
+}
+
+// validateHandler checks a handler can be generated as a scene method.
+func validateHandler(handler string) error {
+	if !token.IsIdentifier(handler) || !token.IsExported(handler) {
+		return fmt.Errorf("handler %q is not an exported Go identifier", handler)
+	}
+
+	if _, found := sceneMethods[handler]; found {
+		return fmt.Errorf("handler %q is already a scene method", handler)
+	}
+
+	return nil
+}
+
+// SceneHandlers are the handler methods used by the events of our visuals
+// in the order they are first used.
+func SceneHandlers(visuals []*SceneVisual) []string {
+	var handlers []string
+
+	var walk func(visual *SceneVisual)
+	walk = func(visual *SceneVisual) {
+		for _, event := range eventNames {
+			if handler, found := visual.Events[event]; found && !contains(handlers, handler) {
+				handlers = append(handlers, handler)
+			}
+		}
+
+		for _, c := range visual.Children {
+			walk(c)
+		}
+	}
+
+	for _, v := range visuals {
+		walk(v)
+	}
+
+	return handlers
+}
+
+func eventCommand() *Command {
+	return &Command{
+		Key: "event",
+		Help: func() string {
+			return "list, set or remove the event handlers of our visual"
+		},
+		Validations: []Validation{
+			RequiresVisual(),
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			var b strings.Builder
+			for _, event := range eventNames {
+				if handler, found := editor.Visual().Events[event]; found {
+					WriteFormat(&b, "%v: %v", event, handler)
+				}
+			}
+
+			return b.String(), nil
+		},
+		Subcommands: []*Command{
+			{
+				Key: "set",
+				Help: func() string {
+					return "call a scene method on an event: set <event> <handler>"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					switch len(partial) {
+					case 1:
+						return Filter(partial[0], eventNames, StringUnchanged)
+					case 2:
+						return Filter(partial[1], SceneHandlers(editor.SceneData().Visuals), StringUnchanged)
+					default:
+						return nil
+					}
+				},
+				Validations: []Validation{
+					RequiresVisual(),
+					RequiredArgs(2),
+					ArgsIn(0, eventNames),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					if err := validateHandler(args[1]); err != nil {
+						return "", err
+					}
+
+					if editor.Visual().Events == nil {
+						editor.Visual().Events = make(map[string]string)
+					}
+
+					editor.Visual().Events[args[0]] = args[1]
+					return "", nil
+				},
+			},
+			{
+				Key: "remove",
+				Help: func() string {
+					return "remove the handler of an event from our visual"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 1 || editor.Visual() == nil {
+						return nil
+					}
+
+					return Filter(partial[0], sortedKeys(editor.Visual().Events), StringUnchanged)
+				},
+				Validations: []Validation{
+					RequiresVisual(),
+					RequiredArgs(1),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					delete(editor.Visual().Events, args[0])
+					if len(editor.Visual().Events) == 0 {
+						editor.Visual().Events = nil
+					}
+
+					return "", nil
+				},
+			},
+		},
+	}
+}
+
+// validateEvents checks our visual only uses known events with handlers
+// that can be generated.
+func validateEvents(visual *SceneVisual, addIssue func(format string, args ...any)) {
+	for _, event := range sortedKeys(visual.Events) {
+		if !contains(eventNames, event) {
+			addIssue("%v: %v", errUnknownEvent, event)
+			continue
+		}
+
+		if err := validateHandler(visual.Events[event]); err != nil {
+			addIssue("%v: %v", event, err)
+		}
+	}
+}
@@ -17,25 +17,7 @@ func helpCommand() *Command {
 
 func helpAction(editor Editor, args []string) (string, error) {
 	if len(args) == 0 {
-		var builder strings.Builder
-		longestKey := 0
-		for _, cmd := range editor.Commands().commands {
-			lKey := len(cmd.Key)
-			if lKey > longestKey {
-				longestKey = lKey
-			}
-		}
-
-		for _, cmd := range editor.Commands().commands {
-			WriteFormat(
-				&builder,
-				"%v%v %v",
-				cmd.Key,
-				strings.Repeat(" ", longestKey-len(cmd.Key)),
-				cmd.Help(),
-			)
-		}
-		return builder.String(), nil
+		return commandsHelp(editor.Commands().commands), nil
 	}
 
 	cmd, _ := FindCommand(nil, editor.Commands().commands, args)
@@ -45,3 +27,26 @@ func helpAction(editor Editor, args []string) (string, error) {
 
 	return cmd.Help(), nil
 }
+
+// commandsHelp lists the key and help of each command with the help aligned.
+func commandsHelp(commands []*Command) string {
+	var builder strings.Builder
+	longestKey := 0
+	for _, cmd := range commands {
+		lKey := len(cmd.Key)
+		if lKey > longestKey {
+			longestKey = lKey
+		}
+	}
+
+	for _, cmd := range commands {
+		WriteFormat(
+			&builder,
+			"%v%v %v",
+			cmd.Key,
+			strings.Repeat(" ", longestKey-len(cmd.Key)),
+			cmd.Help(),
+		)
+	}
+	return builder.String()
+}
@@ -0,0 +1,24 @@
+package commands
+
+func lintCommand() *Command {
+	return &Command{
+		Key: "lint",
+		Help: func() string {
+			return "validate the project and our unsaved scene"
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			issues, err := ValidateProject(map[string]*SceneData{
+				editor.Path(): editor.SceneData(),
+			})
+			if err != nil {
+				return "", err
+			}
+
+			if len(issues) == 0 {
+				return "no issues found", nil
+			}
+
+			return FormatIssues(issues), nil
+		},
+	}
+}
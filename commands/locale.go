@@ -0,0 +1,321 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/miniscruff/igloo/graphics"
+)
+
+var errLocaleNotFound = errors.New("locale not found")
+
+// StringTable is the text of each key in one locale.
+type StringTable map[string]string
+
+// StringTables are our string tables keyed by locale.
+type StringTables map[string]StringTable
+
+// LoadStrings loads every string table in our strings folder keyed by file name.
+func LoadStrings(output *StringTables) error {
+	*output = make(StringTables)
+
+	entries, err := os.ReadDir(filepath.Join(InternalDir, StringsDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failure to read strings: %w", err)
+	}
+
+	for _, entry := range entries {
+		n := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(n, ".json") {
+			continue
+		}
+
+		locale := strings.TrimSuffix(n, ".json")
+		tableBytes, err := os.ReadFile(StringsPath(locale))
+		if err != nil {
+			return err
+		}
+
+		var table StringTable
+		if err := json.Unmarshal(tableBytes, &table); err != nil {
+			return fmt.Errorf("failure to load strings %v: %w", n, err)
+		}
+
+		(*output)[locale] = table
+	}
+
+	return nil
+}
+
+func StringsPath(locale string) string {
+	return filepath.Join(InternalDir, StringsDir, locale+".json")
+}
+
+func (t StringTables) Locales() []string {
+	return sortedKeys(t)
+}
+
+// Lookup is the text of a key in a locale, falling back to the
+// default locale and then the key itself.
+func (t StringTables) Lookup(locale, defaultLocale, key string) string {
+	if text, found := t[locale][key]; found {
+		return text
+	}
+
+	if text, found := t[defaultLocale][key]; found {
+		return text
+	}
+
+	return key
+}
+
+// LocalizedText is the text of a key in the locale our editor previews.
+func LocalizedText(editor Editor, key string) string {
+	return editor.Strings().Lookup(editor.Locale(), editor.Metadata().LocaleOrDefault(), key)
+}
+
+// LocalizeVisuals sets the text of every label with a text key,
+// including the labels of expanded prefabs.
+func LocalizeVisuals(editor Editor, visuals []*SceneVisual) {
+	for _, v := range visuals {
+		if label, ok := v.Instance.(*graphics.LabelVisual); ok && v.Label.TextKey != "" {
+			label.SetText(LocalizedText(editor, v.Label.TextKey))
+		}
+
+		if v.Expanded != nil {
+			LocalizeVisuals(editor, []*SceneVisual{v.Expanded})
+		}
+
+		LocalizeVisuals(editor, v.Children)
+	}
+}
+
+func textKeySuggestions(editor Editor, partial []string) []string {
+	if len(partial) != 1 {
+		return nil
+	}
+
+	keys := make(map[string]struct{})
+	for _, table := range editor.Strings() {
+		for key := range table {
+			keys[key] = struct{}{}
+		}
+	}
+
+	return Filter(partial[0], sortedKeys(keys), StringUnchanged)
+}
+
+func localeCommand() *Command {
+	return &Command{
+		Key: "locale",
+		Help: func() string {
+			return "list locales or preview our scene in a locale"
+		},
+		Suggestions: func(editor Editor, partial []string) []string {
+			if len(partial) != 1 {
+				return nil
+			}
+
+			return Filter(partial[0], editor.Strings().Locales(), StringUnchanged)
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			if len(args) == 0 {
+				var b strings.Builder
+				for _, locale := range editor.Strings().Locales() {
+					marker := ""
+					if locale == editor.Locale() {
+						marker = " *"
+					}
+
+					WriteFormat(&b, "%v: %v strings%v", locale, len(editor.Strings()[locale]), marker)
+				}
+
+				return b.String(), nil
+			}
+
+			if _, found := editor.Strings()[args[0]]; !found {
+				return "", fmt.Errorf("%w: %v", errLocaleNotFound, args[0])
+			}
+
+			editor.SetLocale(args[0])
+			return "", nil
+		},
+	}
+}
+
+// fontMeasurer measures text with the faces of our font content,
+// loading each face once.
+type fontMeasurer struct {
+	content  map[string]Content
+	assets   map[string]Asset
+	metadata Metadata
+	faces    map[string]font.Face
+}
+
+func newFontMeasurer(content map[string]Content, assets map[string]Asset, metadata Metadata) *fontMeasurer {
+	return &fontMeasurer{
+		content:  content,
+		assets:   assets,
+		metadata: metadata,
+		faces:    make(map[string]font.Face),
+	}
+}
+
+// Width is the width of the widest line of a text in pixels.
+func (m *fontMeasurer) Width(fontKey, text string) (float64, error) {
+	face, found := m.faces[fontKey]
+	if !found {
+		c := m.content[fontKey]
+		if c.Type != ContentFont {
+			return 0, fmt.Errorf("%v is not font content", fontKey)
+		}
+
+		fontBytes, err := os.ReadFile(filepath.Join(m.metadata.AssetsPath, m.assets[c.Font.Asset].File))
+		if err != nil {
+			return 0, err
+		}
+
+		fontAsset, err := opentype.Parse(fontBytes)
+		if err != nil {
+			return 0, err
+		}
+
+		face, err = opentype.NewFace(fontAsset, &opentype.FaceOptions{
+			Size: float64(c.Font.Size),
+			DPI:  float64(c.Font.DPI),
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		m.faces[fontKey] = face
+	}
+
+	var width fixed.Int26_6
+	for _, line := range strings.Split(text, "\n") {
+		if lineWidth := font.MeasureString(face, line); lineWidth > width {
+			width = lineWidth
+		}
+	}
+
+	return float64(width.Ceil()), nil
+}
+
+func (m *fontMeasurer) Close() {
+	for _, face := range m.faces {
+		face.Close()
+	}
+}
+
+// validateLabelText checks every text key of our labels has a translation
+// and fits within the width of its label in each locale.
+func validateLabelText(
+	file string,
+	visuals []*SceneVisual,
+	tables StringTables,
+	measure func(fontKey, text string) (float64, error),
+) []Issue {
+	var issues []Issue
+
+	var walk func(visual *SceneVisual, parentPath string)
+	walk = func(visual *SceneVisual, parentPath string) {
+		path := visual.Name
+		if parentPath != "" {
+			path = parentPath + "/" + visual.Name
+		}
+
+		addIssue := func(format string, args ...any) {
+			issues = append(issues, Issue{
+				File:    file,
+				Path:    path,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		key := visual.Label.TextKey
+		if visual.Type == LabelVisualType && key != "" {
+			found := false
+			for _, locale := range tables.Locales() {
+				text, ok := tables[locale][key]
+				if !ok {
+					continue
+				}
+
+				found = true
+				if visual.Transform.Width <= 0 {
+					continue
+				}
+
+				width, err := measure(visual.Label.Content, text)
+				if err != nil {
+					addIssue("unable to measure text: %v", err)
+					break
+				}
+
+				if width > visual.Transform.Width {
+					addIssue("text %v is %v wide in %v, label is %v wide", key, width, locale, visual.Transform.Width)
+				}
+			}
+
+			if !found {
+				addIssue("text key %v not found in any string table", key)
+			}
+		}
+
+		for _, c := range visual.Children {
+			walk(c, path)
+		}
+	}
+
+	for _, v := range visuals {
+		walk(v, "")
+	}
+
+	return issues
+}
+
+// validateStrings flags keys missing from some of our string tables.
+func validateStrings(tables StringTables) []Issue {
+	var issues []Issue
+
+	keys := make(map[string]string)
+	for _, locale := range tables.Locales() {
+		for key := range tables[locale] {
+			if _, found := keys[key]; !found {
+				keys[key] = locale
+			}
+		}
+	}
+
+	for _, locale := range tables.Locales() {
+		var missing []string
+		for key := range keys {
+			if _, found := tables[locale][key]; !found {
+				missing = append(missing, key)
+			}
+		}
+		sort.Strings(missing)
+
+		for _, key := range missing {
+			issues = append(issues, Issue{
+				File:    filepath.ToSlash(filepath.Join(StringsDir, locale+".json")),
+				Path:    key,
+				Message: fmt.Sprintf("missing translation, found in %v", keys[key]),
+			})
+		}
+	}
+
+	return issues
+}
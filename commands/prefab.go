@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const maxPrefabDepth = 16
+
+var (
+	errPrefabNotFound  = errors.New("prefab not found")
+	errPrefabRecursion = errors.New("prefabs nested too deep, is a prefab using itself")
+	errOverrideTarget  = errors.New("prefab override target not found")
+)
+
+// LoadPrefabs loads every prefab in our prefabs folder keyed by file name.
+func LoadPrefabs(output *map[string]*SceneVisual) error {
+	*output = make(map[string]*SceneVisual)
+
+	entries, err := os.ReadDir(filepath.Join(InternalDir, PrefabsDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failure to read prefabs: %w", err)
+	}
+
+	for _, entry := range entries {
+		n := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(n, ".json") {
+			continue
+		}
+
+		prefabBytes, err := os.ReadFile(PrefabPath(strings.TrimSuffix(n, ".json")))
+		if err != nil {
+			return err
+		}
+
+		var prefab SceneVisual
+		if err := json.Unmarshal(prefabBytes, &prefab); err != nil {
+			return fmt.Errorf("failure to load prefab %v: %w", n, err)
+		}
+
+		(*output)[strings.TrimSuffix(n, ".json")] = &prefab
+	}
+
+	return nil
+}
+
+func PrefabPath(name string) string {
+	return filepath.Join(InternalDir, PrefabsDir, name+".json")
+}
+
+// ExpandPrefab builds a copy of the prefab an instance uses, taking the name,
+// transform and visibility of the instance and applying its overrides.
+// Visuals from the prefab are renamed with the instance name as a prefix
+// so multiple instances of a prefab do not share names.
+// Nested instances inside the prefab are expanded as well,
+// the children of the instance itself are left to the caller.
+func ExpandPrefab(instance *SceneVisual, prefabs map[string]*SceneVisual) (*SceneVisual, error) {
+	return expandPrefab(instance, prefabs, 0)
+}
+
+func expandPrefab(instance *SceneVisual, prefabs map[string]*SceneVisual, depth int) (*SceneVisual, error) {
+	if depth > maxPrefabDepth {
+		return nil, fmt.Errorf("%w: %v", errPrefabRecursion, instance.Prefab.Prefab)
+	}
+
+	prefab, found := prefabs[instance.Prefab.Prefab]
+	if !found {
+		return nil, fmt.Errorf("%w: %v", errPrefabNotFound, instance.Prefab.Prefab)
+	}
+
+	var expanded SceneVisual
+	if err := copyJSON(prefab, &expanded); err != nil {
+		return nil, err
+	}
+
+	for path, override := range instance.Prefab.Overrides {
+		target := findPrefabChild(&expanded, path)
+		if target == nil {
+			return nil, fmt.Errorf("%w: %v in %v", errOverrideTarget, path, instance.Name)
+		}
+
+		if err := applyOverride(target, override); err != nil {
+			return nil, fmt.Errorf("failure to override %v in %v: %w", path, instance.Name, err)
+		}
+	}
+
+	expanded.Name = instance.Name
+	expanded.UseWindowSize = instance.UseWindowSize
+	expanded.Visible = instance.Visible
+	expanded.Transform = instance.Transform
+
+	if err := expandChildren(&expanded, prefabs, depth); err != nil {
+		return nil, err
+	}
+
+	for _, c := range expanded.Children {
+		prefixNames(c, instance.Name)
+	}
+
+	if expanded.Type == PrefabVisualType {
+		nested, err := expandPrefab(&expanded, prefabs, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		nested.Children = append(nested.Children, expanded.Children...)
+		return nested, nil
+	}
+
+	return &expanded, nil
+}
+
+func expandChildren(visual *SceneVisual, prefabs map[string]*SceneVisual, depth int) error {
+	for i, child := range visual.Children {
+		if err := expandChildren(child, prefabs, depth); err != nil {
+			return err
+		}
+
+		if child.Type != PrefabVisualType {
+			continue
+		}
+
+		expanded, err := expandPrefab(child, prefabs, depth+1)
+		if err != nil {
+			return err
+		}
+
+		expanded.Children = append(expanded.Children, child.Children...)
+		visual.Children[i] = expanded
+	}
+
+	return nil
+}
+
+// InlinePrefabs replaces every prefab instance with its expanded visuals.
+func InlinePrefabs(visuals []*SceneVisual, prefabs map[string]*SceneVisual) ([]*SceneVisual, error) {
+	inlined := make([]*SceneVisual, 0, len(visuals))
+
+	for _, v := range visuals {
+		var visual *SceneVisual
+
+		if v.Type == PrefabVisualType {
+			expanded, err := ExpandPrefab(v, prefabs)
+			if err != nil {
+				return nil, err
+			}
+
+			visual = expanded
+		} else {
+			copied := *v
+			copied.Children = nil
+			visual = &copied
+		}
+
+		children, err := InlinePrefabs(v.Children, prefabs)
+		if err != nil {
+			return nil, err
+		}
+
+		visual.Children = append(visual.Children, children...)
+		inlined = append(inlined, visual)
+	}
+
+	return inlined, nil
+}
+
+func prefixNames(visual *SceneVisual, prefix string) {
+	visual.Name = prefix + visual.Name
+	for _, c := range visual.Children {
+		prefixNames(c, prefix)
+	}
+}
+
+// findPrefabChild finds a visual by a slash separated path of child names,
+// an empty path is the visual itself.
+func findPrefabChild(visual *SceneVisual, path string) *SceneVisual {
+	if path == "" {
+		return visual
+	}
+
+	name, rest, _ := strings.Cut(path, "/")
+	for _, c := range visual.Children {
+		if c.Name == name {
+			return findPrefabChild(c, rest)
+		}
+	}
+
+	return nil
+}
+
+// applyOverride merges a partial visual into our visual,
+// null values reset a property to its default.
+func applyOverride(visual *SceneVisual, override json.RawMessage) error {
+	var base map[string]any
+	if err := copyJSON(visual, &base); err != nil {
+		return err
+	}
+
+	var patch map[string]any
+	if err := json.Unmarshal(override, &patch); err != nil {
+		return err
+	}
+
+	children := visual.Children
+	*visual = SceneVisual{}
+	if err := copyJSON(mergeJSON(base, patch), visual); err != nil {
+		return err
+	}
+
+	if _, found := patch["children"]; !found {
+		visual.Children = children
+	}
+
+	return nil
+}
+
+func mergeJSON(base, patch map[string]any) map[string]any {
+	for k, v := range patch {
+		if v == nil {
+			delete(base, k)
+			continue
+		}
+
+		patchObj, patchIsObj := v.(map[string]any)
+		baseObj, baseIsObj := base[k].(map[string]any)
+		if patchIsObj && baseIsObj {
+			base[k] = mergeJSON(baseObj, patchObj)
+		} else {
+			base[k] = v
+		}
+	}
+
+	return base
+}
+
+func copyJSON(from, to any) error {
+	fromBytes, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(fromBytes, to)
+}
@@ -0,0 +1,448 @@
+package commands
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	errUnknownPxoFormat  = errors.New("unknown pixelorama project format")
+	errUnsupportedPxo    = errors.New("unsupported compression mode")
+	errTruncatedPxoImage = errors.New("project ends before all cel images")
+	errCorruptPxo        = errors.New("compressed project sizes do not match its data")
+	errEmptyPxo          = errors.New("project has no size")
+	errPxoFileInUse      = errors.New("file is not from this project")
+)
+
+const (
+	godotCompressedMagic = "GCPF"
+	godotDeflate         = 1
+	godotZstd            = 2
+	godotGzip            = 3
+	// godotZstdMemory is the least memory our zstd decoder may use, frames can
+	// declare a window larger than their block
+	godotZstdMemory = 1 << 20
+	pxoDataFile     = "data.json"
+)
+
+// PxoProject is a Pixelorama project along with the image of every cel,
+// layers are ordered from the bottom up.
+type PxoProject struct {
+	Width  int        `json:"size_x"`
+	Height int        `json:"size_y"`
+	Layers []PxoLayer `json:"layers"`
+	Frames []PxoFrame `json:"frames"`
+}
+
+type PxoLayer struct {
+	Name    string `json:"name"`
+	Visible bool   `json:"visible"`
+}
+
+// PxoFrame has a cel for each layer, duration is a multiple of the project fps.
+type PxoFrame struct {
+	Cels     []PxoCel `json:"cels"`
+	Duration float64  `json:"duration"`
+}
+
+type PxoCel struct {
+	Opacity float64      `json:"opacity"`
+	Image   *image.NRGBA `json:"-"`
+}
+
+// ReadPxo reads a Pixelorama project, both the zip archives saved since
+// v0.11 and the older Godot compressed files are supported.
+func ReadPxo(pxoPath string) (PxoProject, error) {
+	data, err := os.ReadFile(pxoPath)
+	if err != nil {
+		return PxoProject{}, err
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte("PK")):
+		return readPxoArchive(data)
+	case bytes.HasPrefix(data, []byte(godotCompressedMagic)):
+		stream, err := decompressGodotFile(data)
+		if err != nil {
+			return PxoProject{}, err
+		}
+
+		return readPxoStream(stream)
+	default:
+		return PxoProject{}, errUnknownPxoFormat
+	}
+}
+
+// readPxoArchive reads data.json and the raw cel images stored by frame
+// and layer number starting at one.
+func readPxoArchive(data []byte) (PxoProject, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return PxoProject{}, err
+	}
+
+	var project PxoProject
+	if err := readZipFile(archive, pxoDataFile, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&project)
+	}); err != nil {
+		return PxoProject{}, err
+	}
+
+	if project.Width <= 0 || project.Height <= 0 {
+		return PxoProject{}, errEmptyPxo
+	}
+
+	for f, frame := range project.Frames {
+		for l := range frame.Cels {
+			celImage := image.NewNRGBA(image.Rect(0, 0, project.Width, project.Height))
+			celPath := fmt.Sprintf("image_data/frames/%v/layer_%v", f+1, l+1)
+
+			err := readZipFile(archive, celPath, func(r io.Reader) error {
+				_, err := io.ReadFull(r, celImage.Pix)
+				return err
+			})
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return PxoProject{}, fmt.Errorf("%v: %w", celPath, err)
+			}
+
+			frame.Cels[l].Image = celImage
+		}
+	}
+
+	return project, nil
+}
+
+func readZipFile(archive *zip.Reader, name string, read func(r io.Reader) error) error {
+	file, err := archive.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return read(file)
+}
+
+// readPxoStream reads the project JSON on the first line followed by
+// the raw cel images of each frame.
+func readPxoStream(stream []byte) (PxoProject, error) {
+	line, rest, found := bytes.Cut(stream, []byte("\n"))
+	if !found {
+		return PxoProject{}, errUnknownPxoFormat
+	}
+
+	var project PxoProject
+	if err := json.Unmarshal(line, &project); err != nil {
+		return PxoProject{}, err
+	}
+
+	if project.Width <= 0 || project.Height <= 0 {
+		return PxoProject{}, errEmptyPxo
+	}
+
+	celSize := project.Width * project.Height * 4
+	for _, frame := range project.Frames {
+		for l := range frame.Cels {
+			if len(rest) < celSize {
+				return PxoProject{}, errTruncatedPxoImage
+			}
+
+			celImage := image.NewNRGBA(image.Rect(0, 0, project.Width, project.Height))
+			copy(celImage.Pix, rest[:celSize])
+			rest = rest[celSize:]
+
+			frame.Cels[l].Image = celImage
+		}
+	}
+
+	return project, nil
+}
+
+// decompressGodotFile reads a file written by Godot's File.open_compressed,
+// a header followed by independently compressed blocks.
+// Sizes in the header are checked against our data before allocating,
+// so a corrupt file can not request more memory than it decompresses to.
+func decompressGodotFile(data []byte) ([]byte, error) {
+	var header struct {
+		Magic     [4]byte
+		Mode      uint32
+		BlockSize uint32
+		Size      uint32
+	}
+
+	reader := bytes.NewReader(data)
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+
+	if header.BlockSize == 0 {
+		return nil, errUnknownPxoFormat
+	}
+
+	blockCount := (uint64(header.Size) + uint64(header.BlockSize) - 1) / uint64(header.BlockSize)
+	if blockCount*4 > uint64(reader.Len()) {
+		return nil, errCorruptPxo
+	}
+
+	blockSizes := make([]uint32, blockCount)
+	if err := binary.Read(reader, binary.LittleEndian, blockSizes); err != nil {
+		return nil, err
+	}
+
+	var zstdDecoder *zstd.Decoder
+	if header.Mode == godotZstd {
+		var err error
+		memory := uint64(header.BlockSize)
+		if memory < godotZstdMemory {
+			memory = godotZstdMemory
+		}
+
+		zstdDecoder, err = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(memory))
+		if err != nil {
+			return nil, err
+		}
+		defer zstdDecoder.Close()
+	}
+
+	// blocks decompress to at most our block size, the output grows as we
+	// decode them instead of trusting the size in our header
+	output := make([]byte, 0, minInt(int(header.Size), len(data)))
+	for _, blockSize := range blockSizes {
+		if int64(blockSize) > int64(reader.Len()) {
+			return nil, errCorruptPxo
+		}
+
+		block := make([]byte, blockSize)
+		if _, err := io.ReadFull(reader, block); err != nil {
+			return nil, err
+		}
+
+		var (
+			decoded []byte
+			err     error
+		)
+
+		limit := int64(header.BlockSize)
+		switch header.Mode {
+		case godotDeflate:
+			decoded, err = readAllFrom(limit)(zlib.NewReader(bytes.NewReader(block)))
+		case godotZstd:
+			decoded, err = zstdDecoder.DecodeAll(block, nil)
+		case godotGzip:
+			decoded, err = readAllFrom(limit)(gzip.NewReader(bytes.NewReader(block)))
+		default:
+			return nil, fmt.Errorf("%w: %v", errUnsupportedPxo, header.Mode)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if int64(len(decoded)) > limit {
+			return nil, errCorruptPxo
+		}
+
+		output = append(output, decoded...)
+	}
+
+	if uint64(len(output)) != uint64(header.Size) {
+		return nil, errCorruptPxo
+	}
+
+	return output, nil
+}
+
+// readAllFrom reads a decompressed block up to one byte past limit,
+// enough to tell the block is larger than limit without reading all of it.
+func readAllFrom(limit int64) func(reader io.ReadCloser, err error) ([]byte, error) {
+	return func(reader io.ReadCloser, err error) ([]byte, error) {
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		return io.ReadAll(io.LimitReader(reader, limit+1))
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// ImportPxo writes the images of a Pixelorama project into our assets path
+// and registers each as an image asset and sprite content, importing again
+// overwrites the images and keeps the existing keys.
+// By default every layer is its own image, flatten combines the visible
+// layers of each frame instead. Image files are prefixed with our project name
+// and files of other assets are never overwritten.
+func ImportPxo(metadata Metadata, pxoPath string, flatten bool) ([]string, error) {
+	project, err := ReadPxo(pxoPath)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", pxoPath, err)
+	}
+
+	images := make(map[string]image.Image)
+	var files []string
+	addImage := func(img image.Image, name string, frame int) error {
+		file := pxoFileName(name, frame, len(project.Frames)) + ".png"
+		if _, found := images[file]; found {
+			return fmt.Errorf("%w: more than one layer is named %v", errKeyInUse, name)
+		}
+
+		images[file] = img
+		files = append(files, file)
+		return nil
+	}
+
+	projectName := strings.TrimSuffix(filepath.Base(pxoPath), filepath.Ext(pxoPath))
+	for f, frame := range project.Frames {
+		if flatten {
+			if err := addImage(flattenPxoFrame(project, frame), projectName, f); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		for l, cel := range frame.Cels {
+			if l >= len(project.Layers) {
+				break
+			}
+
+			if err := addImage(cel.Image, projectName+"_"+project.Layers[l].Name, f); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var (
+		assets  map[string]Asset
+		content map[string]Content
+	)
+
+	if err := LoadAssets(&assets); err != nil {
+		return nil, err
+	}
+	if err := LoadContent(&content); err != nil {
+		return nil, err
+	}
+
+	source := filepath.ToSlash(filepath.Clean(pxoPath))
+	for _, file := range files {
+		if err := checkPxoFile(metadata, assets, source, file); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]string, 0, len(files))
+	for _, file := range files {
+		assetKey, err := addImageAsset(assets, file, ContentKey(file))
+		if err != nil {
+			return nil, err
+		}
+
+		asset := assets[assetKey]
+		asset.Source = source
+		assets[assetKey] = asset
+
+		if err := setSpriteContent(content, assetKey, assetKey, nil); err != nil {
+			return nil, err
+		}
+
+		var imageBytes bytes.Buffer
+		if err := png.Encode(&imageBytes, images[file]); err != nil {
+			return nil, err
+		}
+
+		err = writeFileAtomic(filepath.Join(metadata.AssetsPath, filepath.FromSlash(file)), imageBytes.Bytes())
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, assetKey)
+	}
+
+	if err := SaveAssets(assets); err != nil {
+		return nil, err
+	}
+
+	return keys, SaveContent(content)
+}
+
+// checkPxoFile makes sure we only overwrite image files a previous import
+// of the same project wrote.
+func checkPxoFile(metadata Metadata, assets map[string]Asset, source, file string) error {
+	for _, k := range sortedKeys(assets) {
+		if assets[k].File != file {
+			continue
+		}
+
+		if assets[k].Type != AssetImage || assets[k].Source != source {
+			return fmt.Errorf("%w: %v is used by asset %v", errPxoFileInUse, file, k)
+		}
+
+		return nil
+	}
+
+	_, err := os.Stat(filepath.Join(metadata.AssetsPath, filepath.FromSlash(file)))
+	if err == nil {
+		return fmt.Errorf("%w: %v already exists", errPxoFileInUse, file)
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// flattenPxoFrame draws the visible layers of a frame from the bottom up.
+func flattenPxoFrame(project PxoProject, frame PxoFrame) image.Image {
+	flat := image.NewNRGBA(image.Rect(0, 0, project.Width, project.Height))
+	for l, cel := range frame.Cels {
+		if l >= len(project.Layers) || !project.Layers[l].Visible {
+			continue
+		}
+
+		opacity := image.NewUniform(color.Alpha{A: uint8(cel.Opacity * 0xff)})
+		draw.DrawMask(flat, flat.Bounds(), cel.Image, image.Point{}, opacity, image.Point{}, draw.Over)
+	}
+
+	return flat
+}
+
+// pxoFileName is a lowercase file name for an image,
+// projects with more than one frame add the frame number starting at one.
+func pxoFileName(name string, frame, frameCount int) string {
+	if frameCount > 1 {
+		name = fmt.Sprintf("%v_%v", name, frame+1)
+	}
+
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return '_'
+	}, name)
+}
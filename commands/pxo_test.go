@@ -0,0 +1,430 @@
+package commands
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// pxoData is a 2x1 project with one frame of two layers.
+const pxoData = `{"size_x":2,"size_y":1,` +
+	`"layers":[{"name":"Back","visible":true},{"name":"Front","visible":false}],` +
+	`"frames":[{"cels":[{"opacity":1},{"opacity":0.5}],"duration":1}]}`
+
+var (
+	backCel  = []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	frontCel = []byte{9, 10, 11, 12, 13, 14, 15, 16}
+)
+
+// pxoArchive is a project saved since Pixelorama v0.11.
+func pxoArchive(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for name, data := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+func validPxoArchive(t *testing.T) []byte {
+	return pxoArchive(t, map[string][]byte{
+		pxoDataFile:                   []byte(pxoData),
+		"image_data/frames/1/layer_1": backCel,
+		"image_data/frames/1/layer_2": frontCel,
+	})
+}
+
+// pxoStream is the project of an older Godot compressed file once decompressed.
+func pxoStream(cels ...[]byte) []byte {
+	stream := append([]byte(pxoData), '\n')
+	for _, cel := range cels {
+		stream = append(stream, cel...)
+	}
+
+	return stream
+}
+
+// godotFile compresses data like Godot's File.open_compressed.
+func godotFile(t *testing.T, mode, blockSize uint32, data []byte) []byte {
+	t.Helper()
+
+	var blocks [][]byte
+	for start := 0; start < len(data); start += int(blockSize) {
+		end := start + int(blockSize)
+		if end > len(data) {
+			end = len(data)
+		}
+
+		blocks = append(blocks, compressGodotBlock(t, mode, data[start:end]))
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(godotCompressedMagic)
+	for _, v := range []uint32{mode, blockSize, uint32(len(data))} {
+		if err := binary.Write(&buffer, binary.LittleEndian, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, block := range blocks {
+		if err := binary.Write(&buffer, binary.LittleEndian, uint32(len(block))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, block := range blocks {
+		buffer.Write(block)
+	}
+
+	return buffer.Bytes()
+}
+
+func compressGodotBlock(t *testing.T, mode uint32, block []byte) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	var writer io.WriteCloser
+	switch mode {
+	case godotDeflate:
+		writer = zlib.NewWriter(&buffer)
+	case godotGzip:
+		writer = gzip.NewWriter(&buffer)
+	case godotZstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer encoder.Close()
+
+		return encoder.EncodeAll(block, nil)
+	default:
+		return append([]byte(nil), block...)
+	}
+
+	if _, err := writer.Write(block); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+// godotHeader is a header followed by the sizes of our blocks and the blocks,
+// without checking they match.
+func godotHeader(t *testing.T, mode, blockSize, size uint32, blocks ...[]byte) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	buffer.WriteString(godotCompressedMagic)
+	for _, v := range []uint32{mode, blockSize, size} {
+		if err := binary.Write(&buffer, binary.LittleEndian, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, block := range blocks {
+		if err := binary.Write(&buffer, binary.LittleEndian, uint32(len(block))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, block := range blocks {
+		buffer.Write(block)
+	}
+
+	return buffer.Bytes()
+}
+
+func checkPxoProject(t *testing.T, project PxoProject, cels ...[]byte) {
+	t.Helper()
+
+	if project.Width != 2 || project.Height != 1 {
+		t.Fatalf("expected a 2x1 project, got %vx%v", project.Width, project.Height)
+	}
+
+	if len(project.Layers) != 2 || project.Layers[0].Name != "Back" || project.Layers[1].Visible {
+		t.Fatalf("unexpected layers %+v", project.Layers)
+	}
+
+	if len(project.Frames) != 1 || len(project.Frames[0].Cels) != len(cels) {
+		t.Fatalf("expected one frame of %v cels, got %+v", len(cels), project.Frames)
+	}
+
+	for i, cel := range cels {
+		if !bytes.Equal(project.Frames[0].Cels[i].Image.Pix, cel) {
+			t.Errorf("cel %v is %v, expected %v", i, project.Frames[0].Cels[i].Image.Pix, cel)
+		}
+	}
+}
+
+func TestReadPxoArchive(t *testing.T) {
+	valid := validPxoArchive(t)
+
+	for _, tc := range []struct {
+		name  string
+		data  []byte
+		cels  [][]byte
+		isErr bool
+	}{
+		{
+			name: "valid",
+			data: valid,
+			cels: [][]byte{backCel, frontCel},
+		},
+		{
+			name: "missing cels are transparent",
+			data: pxoArchive(t, map[string][]byte{
+				pxoDataFile:                   []byte(pxoData),
+				"image_data/frames/1/layer_1": backCel,
+			}),
+			cels: [][]byte{backCel, make([]byte, 8)},
+		},
+		{
+			name: "truncated cel",
+			data: pxoArchive(t, map[string][]byte{
+				pxoDataFile:                   []byte(pxoData),
+				"image_data/frames/1/layer_1": backCel[:5],
+			}),
+			isErr: true,
+		},
+		{
+			name: "missing data",
+			data: pxoArchive(t, map[string][]byte{
+				"image_data/frames/1/layer_1": backCel,
+			}),
+			isErr: true,
+		},
+		{
+			name: "empty size",
+			data: pxoArchive(t, map[string][]byte{
+				pxoDataFile: []byte(`{"size_x":0,"size_y":1,"frames":[{"cels":[{}]}]}`),
+			}),
+			isErr: true,
+		},
+		{
+			name:  "truncated archive",
+			data:  valid[:len(valid)/2],
+			isErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			project, err := readPxoArchive(tc.data)
+			if tc.isErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkPxoProject(t, project, tc.cels...)
+		})
+	}
+}
+
+func TestReadPxoStream(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		stream []byte
+		cels   [][]byte
+		err    error
+	}{
+		{
+			name:   "valid",
+			stream: pxoStream(backCel, frontCel),
+			cels:   [][]byte{backCel, frontCel},
+		},
+		{
+			name:   "truncated cel",
+			stream: pxoStream(backCel, frontCel[:7]),
+			err:    errTruncatedPxoImage,
+		},
+		{
+			name:   "missing cels",
+			stream: pxoStream(),
+			err:    errTruncatedPxoImage,
+		},
+		{
+			name:   "no project line",
+			stream: []byte(pxoData),
+			err:    errUnknownPxoFormat,
+		},
+		{
+			name:   "negative size",
+			stream: []byte(`{"size_x":-2,"size_y":1,"frames":[{"cels":[{}]}]}` + "\n"),
+			err:    errEmptyPxo,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			project, err := readPxoStream(tc.stream)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkPxoProject(t, project, tc.cels...)
+		})
+	}
+}
+
+func TestDecompressGodotFile(t *testing.T) {
+	stream := pxoStream(backCel, frontCel)
+	deflate := godotFile(t, godotDeflate, 16, stream)
+
+	for _, tc := range []struct {
+		name  string
+		data  []byte
+		err   error
+		isErr bool
+	}{
+		{name: "deflate", data: deflate},
+		{name: "zstd", data: godotFile(t, godotZstd, 16, stream)},
+		{name: "gzip", data: godotFile(t, godotGzip, 16, stream)},
+		{name: "single block", data: godotFile(t, godotDeflate, 4096, stream)},
+		{
+			name: "unsupported mode",
+			data: godotFile(t, 7, 16, stream),
+			err:  errUnsupportedPxo,
+		},
+		{
+			name: "zero block size",
+			data: godotHeader(t, godotDeflate, 0, 16),
+			err:  errUnknownPxoFormat,
+		},
+		{
+			name:  "truncated header",
+			data:  deflate[:10],
+			isErr: true,
+		},
+		{
+			name: "truncated block",
+			data: deflate[:len(deflate)-1],
+			err:  errCorruptPxo,
+		},
+		{
+			name: "size larger than our data",
+			data: godotHeader(t, godotDeflate, 1, 0xffffffff),
+			err:  errCorruptPxo,
+		},
+		{
+			name: "block size larger than our data",
+			data: append(godotHeader(t, godotDeflate, 16, 16), 0xff, 0xff, 0xff, 0x7f),
+			err:  errCorruptPxo,
+		},
+		{
+			name: "deflate block larger than our block size",
+			data: godotHeader(t, godotDeflate, 4, 4, compressGodotBlock(t, godotDeflate, make([]byte, 64))),
+			err:  errCorruptPxo,
+		},
+		{
+			name: "zstd block larger than our block size",
+			data: godotHeader(t, godotZstd, 4, 4, compressGodotBlock(t, godotZstd, make([]byte, 64))),
+			err:  errCorruptPxo,
+		},
+		{
+			name: "blocks shorter than our size",
+			data: godotHeader(t, godotGzip, 16, 8, compressGodotBlock(t, godotGzip, backCel[:4])),
+			err:  errCorruptPxo,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := decompressGodotFile(tc.data)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if tc.isErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(output, stream) {
+				t.Fatalf("expected %q, got %q", stream, output)
+			}
+		})
+	}
+}
+
+func TestReadPxo(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{name: "archive", data: validPxoArchive(t)},
+		{name: "godot", data: godotFile(t, godotZstd, 16, pxoStream(backCel, frontCel))},
+		{name: "unknown", data: []byte("not a project"), err: errUnknownPxoFormat},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pxoPath := filepath.Join(dir, tc.name+".pxo")
+			if err := os.WriteFile(pxoPath, tc.data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			project, err := ReadPxo(pxoPath)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkPxoProject(t, project, backCel, frontCel)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package commands
+
+import "errors"
+
+const forceFlag = "--force"
+
+var (
+	errUnsavedChanges = errors.New("scene has unsaved changes, write first or use: quit --force")
+
+	forceOptions = []string{forceFlag}
+)
+
+func quitCommand() *Command {
+	return &Command{
+		Key: "quit",
+		Help: func() string {
+			return "close the editor, add --force to discard unsaved changes"
+		},
+		Suggestions: func(editor Editor, partial []string) []string {
+			if len(partial) != 1 {
+				return nil
+			}
+
+			return Filter(partial[0], forceOptions, StringUnchanged)
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			force := len(args) > 0 && args[0] == forceFlag
+			if editor.Dirty() && !force {
+				return "", errUnsavedChanges
+			}
+
+			editor.Quit()
+			return "", nil
+		},
+	}
+}
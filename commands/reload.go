@@ -0,0 +1,17 @@
+package commands
+
+func reloadCommand() *Command {
+	return &Command{
+		Key: "reload",
+		Help: func() string {
+			return "discard unsaved changes and load the scene from disk"
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			if err := editor.Reload(); err != nil {
+				return "", err
+			}
+
+			return "scene reloaded", nil
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package commands
+
+import "strings"
+
+func restoreCommand() *Command {
+	return &Command{
+		Key: "restore",
+		Help: func() string {
+			return "list scene backups or roll back to one, discarding unsaved changes"
+		},
+		Suggestions: func(editor Editor, partial []string) []string {
+			if len(partial) != 1 {
+				return nil
+			}
+
+			backups, _ := ListSceneBackups(editor.Path())
+			return Filter(partial[0], backups, StringUnchanged)
+		},
+		Run: restoreAction,
+	}
+}
+
+func restoreAction(editor Editor, args []string) (string, error) {
+	if len(args) == 0 {
+		backups, err := ListSceneBackups(editor.Path())
+		if err != nil {
+			return "", err
+		}
+
+		if len(backups) == 0 {
+			return "no backups", nil
+		}
+
+		var builder strings.Builder
+		for _, b := range backups {
+			WriteFormat(&builder, "%v", b)
+		}
+
+		return builder.String(), nil
+	}
+
+	err := RestoreSceneBackup(editor.Path(), args[0], editor.Metadata().BackupCount())
+	if err != nil {
+		return "", err
+	}
+
+	if err := editor.Reload(); err != nil {
+		return "", err
+	}
+
+	return "restored " + args[0], nil
+}
@@ -1,12 +1,19 @@
 package commands
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/miniscruff/igloo"
 	"github.com/miniscruff/igloo/mathf"
+	"github.com/miniscruff/inuit/components"
 )
 
 type AssetType string
@@ -16,32 +23,89 @@ type VisualType string
 const (
 	AssetImage    AssetType = "Image"
 	AssetOpenType AssetType = "OpenType"
+	AssetWav      AssetType = "Wav"
+	AssetOgg      AssetType = "Ogg"
+	AssetMp3      AssetType = "Mp3"
 
 	ContentSprite ContentType = "Sprite"
 	ContentSliced ContentType = "SlicedSprite"
 	ContentFont   ContentType = "Font"
 
+	ContentAnimation ContentType = "Animation"
+
 	EmptyVisualType  VisualType = "Empty"
 	SpriteVisualType VisualType = "Sprite"
 	LabelVisualType  VisualType = "Label"
+	PrefabVisualType VisualType = "Prefab"
+
+	SlicedSpriteVisualType   VisualType = "SlicedSprite"
+	AnimatedSpriteVisualType VisualType = "AnimatedSprite"
+	ButtonVisualType         VisualType = "Button"
+	HBoxVisualType           VisualType = "HBox"
+	VBoxVisualType           VisualType = "VBox"
+	GridVisualType           VisualType = "Grid"
 
 	InternalDir  = ".inuit"
 	AssetsFile   = "_assets.json"
 	ContentsFile = "_content.json"
 	MetadataFile = "_metadata.json"
+	BackupsDir   = "_backups"
+	PrefabsDir   = "prefabs"
+	StringsDir   = "strings"
+
+	DefaultBackups = 5
+	DefaultLocale  = "en"
 )
 
 type Asset struct {
 	Type AssetType `json:"type"`
 	File string    `json:"file"`
+	// Source is the project an imported image was written from,
+	// importing it again may overwrite our file
+	Source string `json:"source,omitempty"`
 }
 
 type BaseContent struct {
 	Asset string `json:"asset"`
 }
 
+// Region is a rectangle of pixels within an image asset.
+type Region struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+func (r Region) Rect() image.Rectangle {
+	return image.Rect(r.X, r.Y, r.X+r.Width, r.Y+r.Height)
+}
+
 type SpriteContent struct {
 	BaseContent
+	// Region limits our sprite to part of the asset, nil uses the whole image
+	Region *Region `json:"region,omitempty"`
+}
+
+// SlicedContent is a sprite split into nine parts,
+// borders are the size in pixels of the parts that do not stretch.
+type SlicedContent struct {
+	BaseContent
+	Region  *Region     `json:"region,omitempty"`
+	Borders mathf.Sides `json:"borders"`
+}
+
+// AnimationContent plays frames in order, each frame is an image asset
+// or a region of one shown for a duration in seconds.
+type AnimationContent struct {
+	Frames []AnimationFrameContent `json:"frames"`
+	Loop   components.LoopMode     `json:"loop,omitempty"`
+}
+
+type AnimationFrameContent struct {
+	BaseContent
+	Region   *Region `json:"region,omitempty"`
+	Duration float64 `json:"duration"`
 }
 
 type FontContent struct {
@@ -52,14 +116,66 @@ type FontContent struct {
 }
 
 type Content struct {
-	Type   ContentType   `json:"type"`
-	Sprite SpriteContent `json:"sprite,omitempty"`
-	Font   FontContent   `json:"font,omitempty"`
+	Type      ContentType      `json:"type"`
+	Sprite    SpriteContent    `json:"sprite,omitempty"`
+	Sliced    SlicedContent    `json:"sliced,omitempty"`
+	Font      FontContent      `json:"font,omitempty"`
+	Animation AnimationContent `json:"animation,omitempty"`
+}
+
+// MarshalJSON only writes the field used by our content type.
+func (c Content) MarshalJSON() ([]byte, error) {
+	output := struct {
+		Type      ContentType       `json:"type"`
+		Sprite    *SpriteContent    `json:"sprite,omitempty"`
+		Sliced    *SlicedContent    `json:"sliced,omitempty"`
+		Font      *FontContent      `json:"font,omitempty"`
+		Animation *AnimationContent `json:"animation,omitempty"`
+	}{
+		Type: c.Type,
+	}
+
+	switch c.Type {
+	case ContentSprite:
+		output.Sprite = &c.Sprite
+	case ContentSliced:
+		output.Sliced = &c.Sliced
+	case ContentFont:
+		output.Font = &c.Font
+	case ContentAnimation:
+		output.Animation = &c.Animation
+	}
+
+	return json.Marshal(output)
 }
 
 type Metadata struct {
 	AssetsPath string `json:"assetsPath"`
 	ScenesPath string `json:"scenesPath"`
+	// AutosaveSeconds saves dirty scenes on an interval, zero disables autosave
+	AutosaveSeconds int `json:"autosaveSeconds,omitempty"`
+	// Backups is how many previous versions of a scene to keep,
+	// zero uses DefaultBackups
+	Backups int `json:"backups,omitempty"`
+	// Locale is the string table our scenes start with and fall back to,
+	// empty uses DefaultLocale
+	Locale string `json:"locale,omitempty"`
+}
+
+func (m Metadata) BackupCount() int {
+	if m.Backups <= 0 {
+		return DefaultBackups
+	}
+
+	return m.Backups
+}
+
+func (m Metadata) LocaleOrDefault() string {
+	if m.Locale == "" {
+		return DefaultLocale
+	}
+
+	return m.Locale
 }
 
 type SceneMetadata struct {
@@ -69,6 +185,7 @@ type SceneMetadata struct {
 type SceneTransform struct {
 	Position mathf.Vec2  `json:"position,omitempty"`
 	Rotation float64     `json:"rotation,omitempty"`
+	Scale    *mathf.Vec2 `json:"scale,omitempty"`
 	Pivot    mathf.Vec2  `json:"pivot,omitempty"`
 	Width    float64     `json:"width,omitempty"`
 	Height   float64     `json:"height,omitempty"`
@@ -76,6 +193,15 @@ type SceneTransform struct {
 	Offsets  mathf.Sides `json:"offsets,omitempty"`
 }
 
+// ScaleOrOne returns our scale, an unset scale draws at full size.
+func (t SceneTransform) ScaleOrOne() mathf.Vec2 {
+	if t.Scale == nil {
+		return mathf.Vec2{X: 1, Y: 1}
+	}
+
+	return *t.Scale
+}
+
 type BaseVisualData struct {
 	Content string `json:"content,omitempty"`
 }
@@ -84,8 +210,19 @@ type SpriteVisualData struct {
 	BaseVisualData
 }
 
+// LabelVisualData shows literal text, or when set the text of a key
+// in the string table of our locale.
 type LabelVisualData struct {
 	BaseVisualData
+	Text    string `json:"text,omitempty"`
+	TextKey string `json:"textKey,omitempty"`
+}
+
+// PrefabVisualData places a prefab, overrides are partial visuals keyed by
+// a slash separated path of child names within the prefab.
+type PrefabVisualData struct {
+	Prefab    string                     `json:"prefab,omitempty"`
+	Overrides map[string]json.RawMessage `json:"overrides,omitempty"`
 }
 
 type SceneVisual struct {
@@ -96,15 +233,89 @@ type SceneVisual struct {
 	Transform     SceneTransform   `json:"transform,omitempty"`
 	Sprite        SpriteVisualData `json:"sprite,omitempty"`
 	Label         LabelVisualData  `json:"label,omitempty"`
-	Children      []*SceneVisual   `json:"children,omitempty"`
-	Parent        *SceneVisual     `json:"-"`
-	Visual        *igloo.Visualer  `json:"-"`
+	Prefab        PrefabVisualData `json:"prefab,omitempty"`
+	Data          json.RawMessage  `json:"data,omitempty"`
+	// Color and Alpha tint our visual
+	Color string   `json:"color,omitempty"`
+	Alpha *float64 `json:"alpha,omitempty"`
+	// Tags and Props are free form metadata for game code to query
+	Tags  []string          `json:"tags,omitempty"`
+	Props map[string]string `json:"props,omitempty"`
+	// Events name the scene methods handling input on our visual
+	Events   map[string]string `json:"events,omitempty"`
+	Children []*SceneVisual    `json:"children,omitempty"`
+	Parent   *SceneVisual      `json:"-"`
+	Visual   *igloo.Visualer   `json:"-"`
+	// Instance is the concrete visual created by our visual kind in the editor
+	Instance any `json:"-"`
+	// Expanded is the copy of the prefab an instance previews
+	Expanded *SceneVisual `json:"-"`
 }
 
 type SceneData struct {
-	Metadata SceneMetadata  `json:"metadata"`
-	Content  []string       `json:"content"`
-	Visuals  []*SceneVisual `json:"visuals"`
+	Metadata  SceneMetadata    `json:"metadata"`
+	Content   []string         `json:"content"`
+	Visuals   []*SceneVisual   `json:"visuals"`
+	Timelines []*SceneTimeline `json:"timelines,omitempty"`
+	// Sounds are the sound assets our scene plays
+	Sounds []string `json:"sounds,omitempty"`
+	// Breakpoints override properties of our visuals by window size
+	Breakpoints []*SceneBreakpoint `json:"breakpoints,omitempty"`
+}
+
+// SceneBreakpoint overrides properties while the window size is within
+// its bounds, a zero bound is not checked.
+type SceneBreakpoint struct {
+	Name      string                `json:"name"`
+	MinWidth  float64               `json:"minWidth,omitempty"`
+	MaxWidth  float64               `json:"maxWidth,omitempty"`
+	MinHeight float64               `json:"minHeight,omitempty"`
+	MaxHeight float64               `json:"maxHeight,omitempty"`
+	Overrides []*BreakpointOverride `json:"overrides,omitempty"`
+}
+
+type BreakpointOverride struct {
+	Visual   string                      `json:"visual"`
+	Property components.TimelineProperty `json:"property"`
+	Value    float64                     `json:"value"`
+}
+
+// SceneTimeline animates properties of our visuals with keyframe tracks.
+type SceneTimeline struct {
+	Name   string           `json:"name"`
+	Loop   bool             `json:"loop,omitempty"`
+	Tracks []*TimelineTrack `json:"tracks"`
+}
+
+type TimelineTrack struct {
+	Visual    string                      `json:"visual"`
+	Property  components.TimelineProperty `json:"property"`
+	Keyframes []TimelineKeyframe          `json:"keyframes"`
+}
+
+// TimelineKeyframe is a value at a time in seconds, ease is the name
+// of the curve from the previous keyframe and defaults to linear.
+type TimelineKeyframe struct {
+	Time  float64 `json:"time"`
+	Value float64 `json:"value"`
+	Ease  string  `json:"ease,omitempty"`
+}
+
+var (
+	errSceneChangedOnDisk = errors.New("scene changed on disk since it was loaded")
+	errSceneDeletedOnDisk = errors.New("scene deleted on disk since it was loaded")
+)
+
+// SceneFileInfo identifies the version of a scene file on disk.
+type SceneFileInfo struct {
+	Hash string
+}
+
+func newSceneFileInfo(data []byte) SceneFileInfo {
+	hash := sha256.Sum256(data)
+	return SceneFileInfo{
+		Hash: hex.EncodeToString(hash[:]),
+	}
 }
 
 func LoadAssets(output *map[string]Asset) error {
@@ -125,6 +336,24 @@ func LoadContent(output *map[string]Content) error {
 	return json.Unmarshal(contentFileBytes, output)
 }
 
+func SaveAssets(assets map[string]Asset) error {
+	outputBytes, err := json.MarshalIndent(assets, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(InternalDir, AssetsFile), outputBytes)
+}
+
+func SaveContent(content map[string]Content) error {
+	outputBytes, err := json.MarshalIndent(content, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(InternalDir, ContentsFile), outputBytes)
+}
+
 func LoadMetadata(output *Metadata) error {
 	metadataFileBytes, err := os.ReadFile(filepath.Join(InternalDir, MetadataFile))
 	if err != nil {
@@ -135,27 +364,94 @@ func LoadMetadata(output *Metadata) error {
 }
 
 func LoadSceneData(output *SceneData, path string) error {
-	sceneFileBytes, err := os.ReadFile(filepath.Join(InternalDir, path))
+	_, err := LoadSceneFile(output, path)
+	return err
+}
+
+// LoadSceneFile loads our scene data along with the version that was read.
+func LoadSceneFile(output *SceneData, path string) (SceneFileInfo, error) {
+	fullPath := filepath.Join(InternalDir, path)
+	sceneFileBytes, err := os.ReadFile(fullPath)
 	if err != nil {
-		return err
+		return SceneFileInfo{}, err
+	}
+
+	if err := json.Unmarshal(sceneFileBytes, output); err != nil {
+		return SceneFileInfo{}, err
 	}
 
-	return json.Unmarshal(sceneFileBytes, output)
+	return newSceneFileInfo(sceneFileBytes), nil
 }
 
-func SaveSceneData(output *SceneData, path string) error {
-	outputFile, err := os.Create(filepath.Join(InternalDir, path))
+// CheckSceneFile returns an error if the scene on disk is no longer
+// the version we loaded, including when it was deleted.
+// The file is always hashed as a write within our mod time resolution
+// keeps the same mod time.
+func CheckSceneFile(path string, loaded SceneFileInfo) error {
+	sceneFileBytes, err := os.ReadFile(filepath.Join(InternalDir, path))
+	if errors.Is(err, os.ErrNotExist) {
+		if loaded.Hash == "" {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %v", errSceneDeletedOnDisk, path)
+	}
 	if err != nil {
 		return err
 	}
 
-	defer outputFile.Close()
+	hash := sha256.Sum256(sceneFileBytes)
+	if hex.EncodeToString(hash[:]) != loaded.Hash {
+		return fmt.Errorf("%w: %v", errSceneChangedOnDisk, path)
+	}
+
+	return nil
+}
 
+// SaveSceneData writes our scene next to the target and renames it into place
+// so a failure never leaves a partial scene, the previous version is kept
+// as one of the last keep backups.
+func SaveSceneData(output *SceneData, path string, keep int) (SceneFileInfo, error) {
 	outputBytes, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return SceneFileInfo{}, err
+	}
+
+	if err := backupScene(path, keep); err != nil {
+		return SceneFileInfo{}, err
+	}
+
+	fullPath := filepath.Join(InternalDir, path)
+	if err := writeFileAtomic(fullPath, outputBytes); err != nil {
+		return SceneFileInfo{}, err
+	}
+
+	return newSceneFileInfo(outputBytes), nil
+}
+
+func writeFileAtomic(target string, data []byte) error {
+	dir, name := filepath.Split(target)
+	tempFile, err := os.CreateTemp(dir, "."+strings.TrimSuffix(name, ".json")+"-*.tmp")
 	if err != nil {
 		return err
 	}
 
-	_, err = outputFile.Write(outputBytes)
-	return err
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath)
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		return err
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, target)
 }
@@ -2,7 +2,12 @@ package commands
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"strconv"
+	"strings"
+
+	"github.com/miniscruff/igloo/mathf"
 )
 
 var (
@@ -15,24 +20,68 @@ var (
 	sideKeys         = []string{"left", "right", "top", "bottom"}
 	vec2Keys         = []string{"x", "y"}
 	ops              = []string{"+", "-", "*", "/", "="}
+	colorOptions     = []string{"none", "#ffffffff"}
 )
 
 func setCommand() *Command {
+	subcommands := []*Command{
+		alphaCommand(),
+		anchorCommand(),
+		colorCommand(),
+		heightCommand(),
+		nameCommand(),
+		pivotCommand(),
+		positionCommand(),
+		scaleCommand(),
+		visibleCommand(),
+		widthCommand(),
+	}
+
+	for _, kind := range VisualKinds() {
+		kindCommands := kind.Commands()
+		if len(kindCommands) == 0 {
+			continue
+		}
+
+		visualType := kind.Type
+		subcommands = append(subcommands, &Command{
+			Key: strings.ToLower(string(visualType)),
+			Help: func() string {
+				return fmt.Sprintf("modify a value of our %v visual", visualType)
+			},
+			Subcommands: kindCommands,
+		})
+	}
+
 	return &Command{
 		Key: "set",
 		Help: func() string {
 			return "modify a value of our visual"
 		},
-		Subcommands: []*Command{
-			anchorCommand(),
-			heightCommand(),
-			nameCommand(),
-			pivotCommand(),
-			positionCommand(),
-			visibleCommand(),
-			widthCommand(),
-		},
+		Subcommands: subcommands,
+	}
+}
+
+// RenameVisual renames a visual along with the timeline tracks
+// and breakpoint overrides that refer to it by name.
+func (s *SceneData) RenameVisual(visual *SceneVisual, name string) {
+	for _, t := range s.Timelines {
+		for _, track := range t.Tracks {
+			if track.Visual == visual.Name {
+				track.Visual = name
+			}
+		}
+	}
+
+	for _, b := range s.Breakpoints {
+		for _, o := range b.Overrides {
+			if o.Visual == visual.Name {
+				o.Visual = name
+			}
+		}
 	}
+
+	visual.Name = name
 }
 
 func nameCommand() *Command {
@@ -45,8 +94,13 @@ func nameCommand() *Command {
 			RequiredArgs(1),
 			RequiresVisual(),
 		},
+		Mutates: true,
 		Run: func(editor Editor, args []string) (string, error) {
-			editor.Visual().Name = args[0]
+			if FindVisual(editor.SceneData().Visuals, args[0]) != nil {
+				return "", fmt.Errorf("%w: %v", errKeyInUse, args[0])
+			}
+
+			editor.SceneData().RenameVisual(editor.Visual(), args[0])
 			return "", nil
 		},
 	}
@@ -70,13 +124,93 @@ func visibleCommand() *Command {
 			RequiredArgs(1),
 			ArgsIn(0, trueFalseOptions),
 		},
+		Mutates: true,
 		Run: func(editor Editor, args []string) (string, error) {
-			editor.Visual().Visual.SetVisible(args[0] == "true")
+			editor.Visual().Visible = args[0] == "true"
+			editor.Visual().Visual.SetVisible(editor.Visual().Visible)
 			return "", nil
 		},
 	}
 }
 
+func colorCommand() *Command {
+	return &Command{
+		Key: "color",
+		Help: func() string {
+			return "tint our visual with a #RRGGBBAA color or none"
+		},
+		Suggestions: func(editor Editor, partial []string) []string {
+			if len(partial) != 1 {
+				return nil
+			}
+
+			return Filter(partial[0], colorOptions, StringUnchanged)
+		},
+		Validations: []Validation{
+			RequiresVisual(),
+			RequiredArgs(1),
+		},
+		Mutates: true,
+		Run: func(editor Editor, args []string) (string, error) {
+			visual := editor.Visual()
+			if args[0] == "none" {
+				visual.Color = ""
+			} else {
+				tint, err := ParseColor(args[0])
+				if err != nil {
+					return "", err
+				}
+
+				visual.Color = FormatColor(tint)
+			}
+
+			return "", visual.ApplyTint(&visual.Visual.ColorM)
+		},
+	}
+}
+
+func alphaCommand() *Command {
+	return &Command{
+		Key: "alpha",
+		Help: func() string {
+			return "set the opacity of our object from 0 to 1"
+		},
+		Suggestions: func(editor Editor, partial []string) []string {
+			switch len(partial) {
+			case 1:
+				return Filter(partial[0], ops, StringUnchanged)
+			default:
+				return nil
+			}
+		},
+		Validations: []Validation{
+			RequiresVisual(),
+			RequiredArgs(2),
+			ArgsIn(0, ops),
+			ArgFloat(1),
+		},
+		Mutates: true,
+		Run: func(editor Editor, args []string) (string, error) {
+			operand, _ := strconv.ParseFloat(args[1], 64)
+			visual := editor.Visual()
+
+			_, alpha, err := visual.Tint()
+			if err != nil {
+				return "", err
+			}
+
+			alpha = math.Max(0, math.Min(1, MathOp(alpha, args[0], operand)))
+			if alpha == 1 {
+				visual.Alpha = nil
+			} else {
+				visual.Alpha = &alpha
+			}
+
+			return "", visual.ApplyTint(&visual.Visual.ColorM)
+		},
+	}
+}
+
 func anchorCommand() *Command {
 	return &Command{
 		Key: "anchor",
@@ -100,6 +234,7 @@ func anchorCommand() *Command {
 			ArgsIn(1, ops),
 			ArgFloat(2),
 		},
+		Mutates: true,
 		Run: func(editor Editor, args []string) (string, error) {
 			operand, _ := strconv.ParseFloat(args[2], 64)
 			var valueFunc func(value float64)
@@ -153,6 +288,7 @@ func heightCommand() *Command {
 			ArgsIn(0, ops),
 			ArgFloat(1),
 		},
+		Mutates: true,
 		Run: func(editor Editor, args []string) (string, error) {
 			operand, _ := strconv.ParseFloat(args[1], 64)
 
@@ -192,6 +328,7 @@ func offsetsCommand() *Command {
 			ArgsIn(1, ops),
 			ArgFloat(2),
 		},
+		Mutates: true,
 		Run: func(editor Editor, args []string) (string, error) {
 			operand, _ := strconv.ParseFloat(args[2], 64)
 			var valueFunc func(value float64)
@@ -248,6 +385,7 @@ func pivotCommand() *Command {
 			ArgsIn(1, ops),
 			ArgFloat(2),
 		},
+		Mutates: true,
 		Run: func(editor Editor, args []string) (string, error) {
 			operand, _ := strconv.ParseFloat(args[2], 64)
 
@@ -298,6 +436,7 @@ func positionCommand() *Command {
 			ArgsIn(1, ops),
 			ArgFloat(2),
 		},
+		Mutates: true,
 		Run: func(editor Editor, args []string) (string, error) {
 			operand, _ := strconv.ParseFloat(args[2], 64)
 
@@ -325,6 +464,53 @@ func positionCommand() *Command {
 	}
 }
 
+func scaleCommand() *Command {
+	return &Command{
+		Key: "scale",
+		Help: func() string {
+			return "set the scale of our object around its pivot"
+		},
+		Suggestions: func(editor Editor, partial []string) []string {
+			switch len(partial) {
+			case 1:
+				return Filter(partial[0], vec2Keys, StringUnchanged)
+			case 2:
+				return Filter(partial[1], ops, StringUnchanged)
+			default:
+				return nil
+			}
+		},
+		Validations: []Validation{
+			RequiresVisual(),
+			RequiredArgs(3),
+			ArgsIn(0, vec2Keys),
+			ArgsIn(1, ops),
+			ArgFloat(2),
+		},
+		Mutates: true,
+		Run: func(editor Editor, args []string) (string, error) {
+			operand, _ := strconv.ParseFloat(args[2], 64)
+			scale := editor.Visual().Transform.ScaleOrOne()
+
+			switch args[0] {
+			case "x":
+				scale.X = MathOp(scale.X, args[1], operand)
+			case "y":
+				scale.Y = MathOp(scale.Y, args[1], operand)
+			}
+
+			editor.Visual().Visual.SetScale(scale)
+			if scale == (mathf.Vec2{X: 1, Y: 1}) {
+				editor.Visual().Transform.Scale = nil
+			} else {
+				editor.Visual().Transform.Scale = &scale
+			}
+
+			return "", nil
+		},
+	}
+}
+
 func widthCommand() *Command {
 	return &Command{
 		Key: "width",
@@ -345,6 +531,7 @@ func widthCommand() *Command {
 			ArgsIn(0, ops),
 			ArgFloat(1),
 		},
+		Mutates: true,
 		Run: func(editor Editor, args []string) (string, error) {
 			operand, _ := strconv.ParseFloat(args[1], 64)
 
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"io"
+
+	"github.com/miniscruff/igloo"
+	"github.com/miniscruff/inuit/components"
+)
+
+// SlicedSpriteVisualData is kept in the data field of sliced sprite visuals.
+type SlicedSpriteVisualData struct {
+	Content string `json:"content"`
+}
+
+func slicedSpriteVisualKind() *VisualKind {
+	return &VisualKind{
+		Type: SlicedSpriteVisualType,
+		NewData: func() any {
+			return &SlicedSpriteVisualData{}
+		},
+		Content: func(visual *SceneVisual) ([]ContentRef, error) {
+			var data SlicedSpriteVisualData
+			if err := visual.DecodeData(&data); err != nil {
+				return nil, err
+			}
+
+			return []ContentRef{{Key: data.Content, Type: ContentSliced}}, nil
+		},
+		New: func(visual *SceneVisual, contentMap map[string]any) (any, *igloo.Visualer, error) {
+			var data SlicedSpriteVisualData
+			if err := visual.DecodeData(&data); err != nil {
+				return nil, nil, err
+			}
+
+			slicedContent, err := LookupContent[*components.SlicedSprite](contentMap, data.Content)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			slicedVis := components.NewSlicedSpriteVisual()
+			slicedVis.SetSlicedSprite(slicedContent)
+			return slicedVis, slicedVis.Visualer, nil
+		},
+		GoType:  "*components.SlicedSpriteVisual",
+		Imports: []string{"github.com/miniscruff/inuit/components"},
+		Generate: func(w io.StringWriter, name string, visual *SceneVisual) error {
+			var data SlicedSpriteVisualData
+			if err := visual.DecodeData(&data); err != nil {
+				return err
+			}
+
+			WriteFormat(w, "%v := components.NewSlicedSpriteVisual()", name)
+			WriteFormat(w, "%v.SetSlicedSprite(content.%v)", name, data.Content)
+			return nil
+		},
+		Commands: func() []*Command {
+			return []*Command{
+				{
+					Key: "content",
+					Help: func() string {
+						return "change the sliced sprite content of our visual"
+					},
+					Suggestions: ContentSuggestions(ContentSliced),
+					Validations: []Validation{
+						RequiresVisualType(SlicedSpriteVisualType),
+						RequiredArgs(1),
+					},
+					Mutates: true,
+					Run: func(editor Editor, args []string) (string, error) {
+						slicedContent, err := LookupContent[*components.SlicedSprite](editor.Content(), args[0])
+						if err != nil {
+							return "", err
+						}
+
+						if err := editor.Visual().EncodeData(SlicedSpriteVisualData{Content: args[0]}); err != nil {
+							return "", err
+						}
+
+						editor.Visual().Instance.(*components.SlicedSpriteVisual).SetSlicedSprite(slicedContent)
+						return "", nil
+					},
+				},
+			}
+		},
+	}
+}
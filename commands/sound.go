@@ -0,0 +1,257 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2/audio/mp3"
+	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+
+	"github.com/miniscruff/inuit/components"
+)
+
+var (
+	errUnknownSoundFormat = errors.New("unknown sound format")
+	errNotSound           = errors.New("asset is not a sound")
+)
+
+// soundExtensions are the asset type of each sound file extension.
+var soundExtensions = map[string]AssetType{
+	".wav": AssetWav,
+	".ogg": AssetOgg,
+	".mp3": AssetMp3,
+}
+
+func (t AssetType) IsSound() bool {
+	return t == AssetWav || t == AssetOgg || t == AssetMp3
+}
+
+// DecodeSound decodes a sound asset with the ebiten decoder of its type.
+func DecodeSound(asset Asset, src io.Reader) (*components.Sound, error) {
+	switch asset.Type {
+	case AssetWav:
+		return components.DecodeSound(src, wav.DecodeWithSampleRate)
+	case AssetOgg:
+		return components.DecodeSound(src, vorbis.DecodeWithSampleRate)
+	case AssetMp3:
+		return components.DecodeSound(src, mp3.DecodeWithSampleRate)
+	default:
+		return nil, fmt.Errorf("%w: %v", errNotSound, asset.File)
+	}
+}
+
+// ImportSound registers a sound file as an asset, files outside our assets
+// path are copied into it once our key is checked. Importing a file again
+// keeps its key and never overwrites files of other assets.
+func ImportSound(metadata Metadata, soundPath, key string) (string, error) {
+	assetType, found := soundExtensions[strings.ToLower(filepath.Ext(soundPath))]
+	if !found {
+		return "", fmt.Errorf("%w: %v", errUnknownSoundFormat, soundPath)
+	}
+
+	copyFile := false
+	file, err := filepath.Rel(metadata.AssetsPath, soundPath)
+	if err != nil || strings.HasPrefix(file, "..") {
+		file = filepath.Base(soundPath)
+		copyFile = true
+	}
+
+	file = filepath.ToSlash(file)
+	if key == "" {
+		key = ContentKey(file)
+	}
+
+	var assets map[string]Asset
+	if err := LoadAssets(&assets); err != nil {
+		return "", err
+	}
+
+	for _, k := range sortedKeys(assets) {
+		if assets[k].File != file {
+			continue
+		}
+
+		if !assets[k].Type.IsSound() {
+			return "", fmt.Errorf("%w: asset %v uses file %v", errKeyInUse, k, file)
+		}
+
+		if copyFile {
+			return k, copySound(metadata, soundPath, file)
+		}
+
+		return k, nil
+	}
+
+	if existing, found := assets[key]; found {
+		return "", fmt.Errorf("%w: asset %v uses file %v", errKeyInUse, key, existing.File)
+	}
+
+	if err := validateName(key); err != nil {
+		return "", err
+	}
+
+	if copyFile {
+		if _, err := os.Stat(filepath.Join(metadata.AssetsPath, file)); err == nil {
+			return "", fmt.Errorf("%w: %v already exists in %v", errKeyInUse, file, metadata.AssetsPath)
+		}
+
+		if err := copySound(metadata, soundPath, file); err != nil {
+			return "", err
+		}
+	}
+
+	assets[key] = Asset{
+		Type: assetType,
+		File: file,
+	}
+
+	return key, SaveAssets(assets)
+}
+
+// copySound copies a sound file from outside our assets path into it.
+func copySound(metadata Metadata, soundPath, file string) error {
+	data, err := os.ReadFile(soundPath)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(metadata.AssetsPath, file), data)
+}
+
+// loadSound decodes a sound asset from disk.
+func loadSound(metadata Metadata, key string) (*components.Sound, error) {
+	var assets map[string]Asset
+	if err := LoadAssets(&assets); err != nil {
+		return nil, err
+	}
+
+	asset, found := assets[key]
+	if !found || !asset.Type.IsSound() {
+		return nil, fmt.Errorf("%w: %v", errNotSound, key)
+	}
+
+	file, err := os.Open(filepath.Join(metadata.AssetsPath, asset.File))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return DecodeSound(asset, file)
+}
+
+func soundAssetKeys() []string {
+	var assets map[string]Asset
+	if err := LoadAssets(&assets); err != nil {
+		return nil
+	}
+
+	var keys []string
+	for _, key := range sortedKeys(assets) {
+		if assets[key].Type.IsSound() {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func soundCommand() *Command {
+	return &Command{
+		Key: "sound",
+		Help: func() string {
+			return "list, add or play the sounds of our scene"
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			return strings.Join(editor.SceneData().Sounds, "\n"), nil
+		},
+		Subcommands: []*Command{
+			{
+				Key: "add",
+				Help: func() string {
+					return "add a sound asset to our scene"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 1 {
+						return nil
+					}
+
+					return Filter(partial[0], soundAssetKeys(), StringUnchanged)
+				},
+				Validations: []Validation{
+					RequiredArgs(1),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					if !contains(soundAssetKeys(), args[0]) {
+						return "", fmt.Errorf("%w: %v", errNotSound, args[0])
+					}
+
+					if contains(editor.SceneData().Sounds, args[0]) {
+						return "", fmt.Errorf("%w: %v", errKeyInUse, args[0])
+					}
+
+					editor.SceneData().Sounds = append(editor.SceneData().Sounds, args[0])
+					return "", nil
+				},
+			},
+			{
+				Key: "remove",
+				Help: func() string {
+					return "remove a sound from our scene"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 1 {
+						return nil
+					}
+
+					return Filter(partial[0], editor.SceneData().Sounds, StringUnchanged)
+				},
+				Validations: []Validation{
+					RequiredArgs(1),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					kept := editor.SceneData().Sounds[:0]
+					for _, s := range editor.SceneData().Sounds {
+						if s != args[0] {
+							kept = append(kept, s)
+						}
+					}
+
+					editor.SceneData().Sounds = kept
+					return "", nil
+				},
+			},
+			{
+				Key: "play",
+				Help: func() string {
+					return "play a sound asset"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 1 {
+						return nil
+					}
+
+					return Filter(partial[0], soundAssetKeys(), StringUnchanged)
+				},
+				Validations: []Validation{
+					RequiredArgs(1),
+				},
+				Run: func(editor Editor, args []string) (string, error) {
+					sound, err := loadSound(*editor.Metadata(), args[0])
+					if err != nil {
+						return "", err
+					}
+
+					sound.Play()
+					return "", nil
+				},
+			},
+		},
+	}
+}
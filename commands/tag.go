@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	errTagNotFound  = errors.New("tag not found")
+	errPropNotFound = errors.New("prop not found")
+)
+
+func tagCommand() *Command {
+	return &Command{
+		Key: "tag",
+		Help: func() string {
+			return "list, add or remove tags of our visual"
+		},
+		Validations: []Validation{
+			RequiresVisual(),
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			return strings.Join(editor.Visual().Tags, "\n"), nil
+		},
+		Subcommands: []*Command{
+			{
+				Key: "add",
+				Help: func() string {
+					return "add a tag to our visual"
+				},
+				Validations: []Validation{
+					RequiresVisual(),
+					RequiredArgs(1),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					for _, t := range editor.Visual().Tags {
+						if t == args[0] {
+							return "", nil
+						}
+					}
+
+					editor.Visual().Tags = append(editor.Visual().Tags, args[0])
+					return "", nil
+				},
+			},
+			{
+				Key: "remove",
+				Help: func() string {
+					return "remove a tag from our visual"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 1 || editor.Visual() == nil {
+						return nil
+					}
+
+					return Filter(partial[0], editor.Visual().Tags, StringUnchanged)
+				},
+				Validations: []Validation{
+					RequiresVisual(),
+					RequiredArgs(1),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					visual := editor.Visual()
+					for i, t := range visual.Tags {
+						if t == args[0] {
+							visual.Tags = append(visual.Tags[:i], visual.Tags[i+1:]...)
+							return "", nil
+						}
+					}
+
+					return "", fmt.Errorf("%w: %v", errTagNotFound, args[0])
+				},
+			},
+		},
+	}
+}
+
+func propCommand() *Command {
+	return &Command{
+		Key: "prop",
+		Help: func() string {
+			return "list, set or remove key value props of our visual"
+		},
+		Validations: []Validation{
+			RequiresVisual(),
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			var builder strings.Builder
+			props := editor.Visual().Props
+
+			keys := make([]string, 0, len(props))
+			for k := range props {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				WriteFormat(&builder, "%v: %v", k, props[k])
+			}
+
+			return builder.String(), nil
+		},
+		Subcommands: []*Command{
+			{
+				Key: "set",
+				Help: func() string {
+					return "set a prop of our visual, prop set <key> <value>"
+				},
+				Validations: []Validation{
+					RequiresVisual(),
+					MinArgs(2),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					if editor.Visual().Props == nil {
+						editor.Visual().Props = make(map[string]string)
+					}
+
+					editor.Visual().Props[args[0]] = strings.Join(args[1:], " ")
+					return "", nil
+				},
+			},
+			{
+				Key: "remove",
+				Help: func() string {
+					return "remove a prop from our visual"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 1 || editor.Visual() == nil {
+						return nil
+					}
+
+					var keys []string
+					for k := range editor.Visual().Props {
+						keys = append(keys, k)
+					}
+					sort.Strings(keys)
+
+					return Filter(partial[0], keys, StringUnchanged)
+				},
+				Validations: []Validation{
+					RequiresVisual(),
+					RequiredArgs(1),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					if _, found := editor.Visual().Props[args[0]]; !found {
+						return "", fmt.Errorf("%w: %v", errPropNotFound, args[0])
+					}
+
+					delete(editor.Visual().Props, args[0])
+					if len(editor.Visual().Props) == 0 {
+						editor.Visual().Props = nil
+					}
+
+					return "", nil
+				},
+			},
+		},
+	}
+}
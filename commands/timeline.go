@@ -0,0 +1,463 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/miniscruff/inuit/components"
+)
+
+var (
+	errTimelineNotFound = errors.New("timeline not found")
+	errVisualNotFound   = errors.New("visual not found")
+	errUnknownProperty  = errors.New("unknown timeline property")
+	errUnknownEase      = errors.New("unknown ease")
+)
+
+// PropertyValue is the value of a timeline property saved in our visual.
+func (v *SceneVisual) PropertyValue(property components.TimelineProperty) (float64, error) {
+	switch property {
+	case components.PropertyX:
+		return v.Transform.Position.X, nil
+	case components.PropertyY:
+		return v.Transform.Position.Y, nil
+	case components.PropertyRotation:
+		return v.Transform.Rotation, nil
+	case components.PropertyAlpha:
+		tint, alpha, err := v.Tint()
+		return alpha * float64(tint.A) / 0xff, err
+	case components.PropertyScaleX:
+		return v.Transform.ScaleOrOne().X, nil
+	case components.PropertyScaleY:
+		return v.Transform.ScaleOrOne().Y, nil
+	case components.PropertyWidth:
+		return v.Transform.Width, nil
+	case components.PropertyHeight:
+		return v.Transform.Height, nil
+	case components.PropertyPivotX:
+		return v.Transform.Pivot.X, nil
+	case components.PropertyPivotY:
+		return v.Transform.Pivot.Y, nil
+	case components.PropertyAnchorLeft:
+		return v.Transform.Anchors.Left, nil
+	case components.PropertyAnchorRight:
+		return v.Transform.Anchors.Right, nil
+	case components.PropertyAnchorTop:
+		return v.Transform.Anchors.Top, nil
+	case components.PropertyAnchorBottom:
+		return v.Transform.Anchors.Bottom, nil
+	case components.PropertyOffsetLeft:
+		return v.Transform.Offsets.Left, nil
+	case components.PropertyOffsetRight:
+		return v.Transform.Offsets.Right, nil
+	case components.PropertyOffsetTop:
+		return v.Transform.Offsets.Top, nil
+	case components.PropertyOffsetBottom:
+		return v.Transform.Offsets.Bottom, nil
+	default:
+		return 0, fmt.Errorf("%w: %v", errUnknownProperty, property)
+	}
+}
+
+// FindVisual searches our visuals and their children by name,
+// including the children of prefabs expanded in our editor.
+func FindVisual(visuals []*SceneVisual, name string) *SceneVisual {
+	for _, v := range visuals {
+		if v.Name == name {
+			return v
+		}
+
+		if found := FindVisual(v.Children, name); found != nil {
+			return found
+		}
+
+		if v.Expanded != nil {
+			if found := FindVisual(v.Expanded.Children, name); found != nil {
+				return found
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *SceneData) Timeline(name string) (*SceneTimeline, error) {
+	for _, t := range s.Timelines {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %v", errTimelineNotFound, name)
+}
+
+// Duration is the time of the last keyframe of our longest track.
+func (t *SceneTimeline) Duration() float64 {
+	var duration float64
+	for _, track := range t.Tracks {
+		if len(track.Keyframes) > 0 {
+			duration = math.Max(duration, track.Keyframes[len(track.Keyframes)-1].Time)
+		}
+	}
+
+	return duration
+}
+
+// SetKeyframe adds a keyframe to the track of a visual property,
+// replacing any keyframe at the same time.
+func (t *SceneTimeline) SetKeyframe(visual string, property components.TimelineProperty, keyframe TimelineKeyframe) {
+	var track *TimelineTrack
+	for _, tr := range t.Tracks {
+		if tr.Visual == visual && tr.Property == property {
+			track = tr
+		}
+	}
+
+	if track == nil {
+		track = &TimelineTrack{
+			Visual:   visual,
+			Property: property,
+		}
+		t.Tracks = append(t.Tracks, track)
+	}
+
+	kept := track.Keyframes[:0]
+	for _, k := range track.Keyframes {
+		if k.Time != keyframe.Time {
+			kept = append(kept, k)
+		}
+	}
+
+	track.Keyframes = append(kept, keyframe)
+	sort.SliceStable(track.Keyframes, func(i, j int) bool {
+		return track.Keyframes[i].Time < track.Keyframes[j].Time
+	})
+}
+
+// Build creates a timeline playing on the editor visuals of our scene.
+func (t *SceneTimeline) Build(visuals []*SceneVisual) (*components.Timeline, error) {
+	timeline := &components.Timeline{
+		Loop: t.Loop,
+	}
+
+	for _, track := range t.Tracks {
+		visual := FindVisual(visuals, track.Visual)
+		if visual == nil || visual.Visual == nil {
+			return nil, fmt.Errorf("%w: %v", errVisualNotFound, track.Visual)
+		}
+
+		keyframes := make([]components.Keyframe, 0, len(track.Keyframes))
+		for _, k := range track.Keyframes {
+			ease, found := components.Eases[easeName(k.Ease)]
+			if !found {
+				return nil, fmt.Errorf("%w: %v", errUnknownEase, k.Ease)
+			}
+
+			keyframes = append(keyframes, components.Keyframe{
+				Time:  k.Time,
+				Value: k.Value,
+				Ease:  ease,
+			})
+		}
+
+		timeline.Tracks = append(timeline.Tracks, components.TimelineTrack{
+			Keyframes: keyframes,
+			Set:       components.TrackSetter(visual.Visual, track.Property),
+		})
+	}
+
+	return timeline, nil
+}
+
+func easeName(ease string) string {
+	if ease == "" {
+		return "Linear"
+	}
+
+	return ease
+}
+
+// restoreTimelines sets every property our timelines change back to
+// the value saved in its visual.
+func restoreTimelines(sceneData *SceneData) error {
+	for _, t := range sceneData.Timelines {
+		for _, track := range t.Tracks {
+			visual := FindVisual(sceneData.Visuals, track.Visual)
+			if visual == nil || visual.Visual == nil {
+				continue
+			}
+
+			if track.Property == components.PropertyAlpha {
+				if err := visual.ApplyTint(&visual.Visual.ColorM); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			value, err := visual.PropertyValue(track.Property)
+			if err != nil {
+				return err
+			}
+
+			components.TrackSetter(visual.Visual, track.Property)(value)
+		}
+	}
+
+	return nil
+}
+
+func timelineSuggestions(editor Editor, partial string) []string {
+	names := make([]string, 0, len(editor.SceneData().Timelines))
+	for _, t := range editor.SceneData().Timelines {
+		names = append(names, t.Name)
+	}
+
+	return Filter(partial, names, StringUnchanged)
+}
+
+func timelinePropertyOptions() []string {
+	options := make([]string, 0, len(components.TimelineProperties))
+	for _, p := range components.TimelineProperties {
+		options = append(options, string(p))
+	}
+
+	return options
+}
+
+func easeOptions() []string {
+	options := make([]string, 0, len(components.Eases))
+	for name := range components.Eases {
+		options = append(options, name)
+	}
+	sort.Strings(options)
+
+	return options
+}
+
+func timelineCommand() *Command {
+	return &Command{
+		Key: "timeline",
+		Help: func() string {
+			return "list, edit or preview the timelines of our scene"
+		},
+		Run: func(editor Editor, args []string) (string, error) {
+			var b strings.Builder
+			for _, t := range editor.SceneData().Timelines {
+				WriteFormat(&b, "%v: %v tracks, %vs, loop %v", t.Name, len(t.Tracks), t.Duration(), t.Loop)
+			}
+
+			return b.String(), nil
+		},
+		Subcommands: []*Command{
+			{
+				Key: "add",
+				Help: func() string {
+					return "add a timeline: add <name> [loop]"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 2 {
+						return nil
+					}
+
+					return Filter(partial[1], []string{"loop"}, StringUnchanged)
+				},
+				Validations: []Validation{
+					MinArgs(1),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					if _, err := editor.SceneData().Timeline(args[0]); err == nil {
+						return "", fmt.Errorf("%w: %v", errKeyInUse, args[0])
+					}
+
+					editor.SceneData().Timelines = append(editor.SceneData().Timelines, &SceneTimeline{
+						Name: args[0],
+						Loop: len(args) > 1 && args[1] == "loop",
+					})
+
+					return "", nil
+				},
+			},
+			{
+				Key: "remove",
+				Help: func() string {
+					return "remove a timeline"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 1 {
+						return nil
+					}
+
+					return timelineSuggestions(editor, partial[0])
+				},
+				Validations: []Validation{
+					RequiredArgs(1),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					sceneData := editor.SceneData()
+					if _, err := sceneData.Timeline(args[0]); err != nil {
+						return "", err
+					}
+
+					kept := sceneData.Timelines[:0]
+					for _, t := range sceneData.Timelines {
+						if t.Name != args[0] {
+							kept = append(kept, t)
+						}
+					}
+
+					sceneData.Timelines = kept
+					return "", nil
+				},
+			},
+			{
+				Key: "key",
+				Help: func() string {
+					return "keyframe a property of our visual as it is now: key <timeline> <property> <seconds> [ease]"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					switch len(partial) {
+					case 1:
+						return timelineSuggestions(editor, partial[0])
+					case 2:
+						return Filter(partial[1], timelinePropertyOptions(), StringUnchanged)
+					case 4:
+						return Filter(partial[3], easeOptions(), StringUnchanged)
+					default:
+						return nil
+					}
+				},
+				Validations: []Validation{
+					RequiresVisual(),
+					MinArgs(3),
+					ArgsIn(1, timelinePropertyOptions()),
+					ArgFloat(2),
+				},
+				Mutates: true,
+				Run: func(editor Editor, args []string) (string, error) {
+					timeline, err := editor.SceneData().Timeline(args[0])
+					if err != nil {
+						return "", err
+					}
+
+					ease := ""
+					if len(args) > 3 {
+						ease = args[3]
+						if _, found := components.Eases[ease]; !found {
+							return "", fmt.Errorf("%w: %v", errUnknownEase, ease)
+						}
+					}
+
+					property := components.TimelineProperty(args[1])
+					value, err := editor.Visual().PropertyValue(property)
+					if err != nil {
+						return "", err
+					}
+
+					seconds, _ := strconv.ParseFloat(args[2], 64)
+					timeline.SetKeyframe(editor.Visual().Name, property, TimelineKeyframe{
+						Time:  seconds,
+						Value: value,
+						Ease:  ease,
+					})
+
+					return fmt.Sprintf("%v %v is %v at %vs", editor.Visual().Name, property, value, seconds), nil
+				},
+			},
+			{
+				Key: "scrub",
+				Help: func() string {
+					return "show a timeline at a time: scrub <timeline> <seconds>"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 1 {
+						return nil
+					}
+
+					return timelineSuggestions(editor, partial[0])
+				},
+				Validations: []Validation{
+					RequiredArgs(2),
+					ArgFloat(1),
+				},
+				Run: func(editor Editor, args []string) (string, error) {
+					timeline, err := buildTimeline(editor, args[0])
+					if err != nil {
+						return "", err
+					}
+
+					seconds, _ := strconv.ParseFloat(args[1], 64)
+					timeline.Seek(seconds)
+					editor.SetTimeline(timeline)
+					return "", nil
+				},
+			},
+			{
+				Key: "preview",
+				Help: func() string {
+					return "play a timeline in the editor"
+				},
+				Suggestions: func(editor Editor, partial []string) []string {
+					if len(partial) != 1 {
+						return nil
+					}
+
+					return timelineSuggestions(editor, partial[0])
+				},
+				Validations: []Validation{
+					RequiredArgs(1),
+				},
+				Run: func(editor Editor, args []string) (string, error) {
+					timeline, err := buildTimeline(editor, args[0])
+					if err != nil {
+						return "", err
+					}
+
+					timeline.Play()
+					editor.SetTimeline(timeline)
+					return "", nil
+				},
+			},
+			{
+				Key: "stop",
+				Help: func() string {
+					return "stop previewing timelines and restore our visuals"
+				},
+				Run: func(editor Editor, args []string) (string, error) {
+					editor.SetTimeline(nil)
+					if err := restoreTimelines(editor.SceneData()); err != nil {
+						return "", err
+					}
+
+					// restoring resets the properties our breakpoints override
+					return "", ApplyBreakpoints(editor)
+				},
+			},
+		},
+	}
+}
+
+// buildTimeline restores our visuals and their breakpoint overrides before
+// building a timeline, so properties of other timelines do not linger.
+func buildTimeline(editor Editor, name string) (*components.Timeline, error) {
+	sceneTimeline, err := editor.SceneData().Timeline(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := restoreTimelines(editor.SceneData()); err != nil {
+		return nil, err
+	}
+
+	if err := ApplyBreakpoints(editor); err != nil {
+		return nil, err
+	}
+
+	return sceneTimeline.Build(editor.SceneData().Visuals)
+}
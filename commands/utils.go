@@ -38,6 +38,10 @@ func ExistingScenes() ([]string, error) {
 
 	for _, dir := range entries {
 		n := dir.Name()
+		if dir.IsDir() || !strings.HasSuffix(n, ".json") {
+			continue
+		}
+
 		if n == AssetsFile || n == ContentsFile || n == MetadataFile {
 			continue
 		}
@@ -105,6 +109,16 @@ func RequiredArgs(count int) Validation {
 	}
 }
 
+func MinArgs(count int) Validation {
+	return func(editor Editor, args []string) error {
+		if len(args) < count {
+			return fmt.Errorf("%v < %v: %w", len(args), count, errIncorrectNumberOfArgs)
+		}
+
+		return nil
+	}
+}
+
 func ArgsIn(index int, options []string) Validation {
 	return func(editor Editor, args []string) error {
 		if len(args) <= index {
@@ -136,6 +150,21 @@ func ArgFloat(index int) Validation {
 	}
 }
 
+func ArgInt(index int) Validation {
+	return func(editor Editor, args []string) error {
+		if len(args) <= index {
+			return errIncorrectNumberOfArgs
+		}
+
+		_, err := strconv.Atoi(args[index])
+		if err != nil {
+			return fmt.Errorf("%w: args[%v] not an int", errInvalidArg, index)
+		}
+
+		return nil
+	}
+}
+
 func RequiresVisual() Validation {
 	return func(editor Editor, args []string) error {
 		if editor.Visual() == nil {
@@ -0,0 +1,450 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/miniscruff/igloo/mathf"
+	"github.com/miniscruff/inuit/components"
+)
+
+//go:generate go run ../cmd/gen -reserved reserved_generated.go
+
+// validateName checks a name can be generated as a Go identifier
+// without colliding with the identifiers of generated scenes.
+func validateName(name string) error {
+	if !token.IsIdentifier(name) {
+		return fmt.Errorf("name %q is not a valid Go identifier", name)
+	}
+
+	if _, reserved := reservedNames[name]; reserved {
+		return fmt.Errorf("name %q is reserved by generated scenes", name)
+	}
+
+	return nil
+}
+
+// Issue is a problem found while validating our project.
+type Issue struct {
+	File    string
+	Path    string
+	Message string
+}
+
+func (i Issue) String() string {
+	if i.Path == "" {
+		return fmt.Sprintf("%v: %v", i.File, i.Message)
+	}
+
+	return fmt.Sprintf("%v: %v: %v", i.File, i.Path, i.Message)
+}
+
+// ValidateProject checks our project files and every scene for problems that
+// would otherwise fail generation or panic in the editor.
+// Scenes in loaded are used instead of reading them from disk.
+func ValidateProject(loaded map[string]*SceneData) ([]Issue, error) {
+	var (
+		assets   map[string]Asset
+		content  map[string]Content
+		metadata Metadata
+		prefabs  map[string]*SceneVisual
+	)
+
+	if err := LoadAssets(&assets); err != nil {
+		return nil, err
+	}
+	if err := LoadContent(&content); err != nil {
+		return nil, err
+	}
+	if err := LoadMetadata(&metadata); err != nil {
+		return nil, err
+	}
+	if err := LoadPrefabs(&prefabs); err != nil {
+		return nil, err
+	}
+
+	sceneNames, err := ExistingScenes()
+	if err != nil {
+		return nil, err
+	}
+
+	var tables StringTables
+	if err := LoadStrings(&tables); err != nil {
+		return nil, err
+	}
+
+	measurer := newFontMeasurer(content, assets, metadata)
+	defer measurer.Close()
+
+	issues := validateAssets(assets, metadata)
+	issues = append(issues, validateContent(content, assets)...)
+	issues = append(issues, validateStrings(tables)...)
+
+	for _, name := range sceneNames {
+		file := name + ".json"
+		scene, found := loaded[file]
+		if !found {
+			scene = &SceneData{}
+			if err := LoadSceneData(scene, file); err != nil {
+				issues = append(issues, Issue{File: file, Message: err.Error()})
+				continue
+			}
+		}
+
+		issues = append(issues, ValidateScene(file, scene, content, prefabs)...)
+		issues = append(issues, validateSounds(file, scene.Sounds, assets)...)
+
+		// prefab errors are already reported by ValidateScene
+		if visuals, err := InlinePrefabs(scene.Visuals, prefabs); err == nil {
+			issues = append(issues, validateLabelText(file, visuals, tables, measurer.Width)...)
+		}
+	}
+
+	return issues, nil
+}
+
+func validateSounds(file string, sounds []string, assets map[string]Asset) []Issue {
+	var issues []Issue
+
+	for _, key := range sounds {
+		a, found := assets[key]
+		if !found {
+			issues = append(issues, Issue{
+				File:    file,
+				Message: fmt.Sprintf("sound %v missing from %v", key, AssetsFile),
+			})
+		} else if !a.Type.IsSound() {
+			issues = append(issues, Issue{
+				File:    file,
+				Message: fmt.Sprintf("sound %v is %v, expected a sound", key, a.Type),
+			})
+		}
+	}
+
+	return issues
+}
+
+func validateAssets(assets map[string]Asset, metadata Metadata) []Issue {
+	var issues []Issue
+
+	for _, key := range sortedKeys(assets) {
+		a := assets[key]
+		if err := validateName(key); err != nil {
+			issues = append(issues, Issue{
+				File:    AssetsFile,
+				Path:    key,
+				Message: err.Error(),
+			})
+		}
+
+		if _, err := os.Stat(filepath.Join(metadata.AssetsPath, a.File)); err != nil {
+			issues = append(issues, Issue{
+				File:    AssetsFile,
+				Path:    key,
+				Message: fmt.Sprintf("file %v not found in %v", a.File, metadata.AssetsPath),
+			})
+		}
+	}
+
+	return issues
+}
+
+func validateContent(content map[string]Content, assets map[string]Asset) []Issue {
+	var issues []Issue
+
+	for _, key := range sortedKeys(content) {
+		c := content[key]
+
+		var assetKey string
+		var region *Region
+		switch c.Type {
+		case ContentSprite:
+			assetKey = c.Sprite.Asset
+			region = c.Sprite.Region
+		case ContentSliced:
+			assetKey = c.Sliced.Asset
+			region = c.Sliced.Region
+		case ContentFont:
+			assetKey = c.Font.Asset
+		case ContentAnimation:
+			issues = append(issues, validateAnimation(key, c.Animation, assets)...)
+			continue
+		default:
+			continue
+		}
+
+		if _, found := assets[assetKey]; !found {
+			issues = append(issues, Issue{
+				File:    ContentsFile,
+				Path:    key,
+				Message: fmt.Sprintf("asset %v missing from %v", assetKey, AssetsFile),
+			})
+		}
+
+		if region != nil && (region.Width <= 0 || region.Height <= 0) {
+			issues = append(issues, Issue{
+				File:    ContentsFile,
+				Path:    key,
+				Message: fmt.Sprintf("region %vx%v is empty", region.Width, region.Height),
+			})
+		}
+	}
+
+	return issues
+}
+
+func validateAnimation(key string, animation AnimationContent, assets map[string]Asset) []Issue {
+	var issues []Issue
+	addIssue := func(format string, args ...any) {
+		issues = append(issues, Issue{
+			File:    ContentsFile,
+			Path:    key,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if len(animation.Frames) == 0 {
+		addIssue("animation has no frames")
+	}
+
+	if animation.Loop != "" && !contains(loopModeOptions(), string(animation.Loop)) {
+		addIssue("unknown loop mode %v", animation.Loop)
+	}
+
+	for i, frame := range animation.Frames {
+		if _, found := assets[frame.Asset]; !found {
+			addIssue("frame %v asset %v missing from %v", i, frame.Asset, AssetsFile)
+		}
+
+		if frame.Duration <= 0 {
+			addIssue("frame %v has no duration", i)
+		}
+
+		if frame.Region != nil && (frame.Region.Width <= 0 || frame.Region.Height <= 0) {
+			addIssue("frame %v region %vx%v is empty", i, frame.Region.Width, frame.Region.Height)
+		}
+	}
+
+	return issues
+}
+
+// ValidateScene checks a single scene against our content and prefabs.
+func ValidateScene(
+	file string,
+	scene *SceneData,
+	content map[string]Content,
+	prefabs map[string]*SceneVisual,
+) []Issue {
+	var issues []Issue
+
+	sceneContent := make(map[string]struct{})
+	for _, key := range scene.Content {
+		sceneContent[key] = struct{}{}
+		if _, found := content[key]; !found {
+			issues = append(issues, Issue{
+				File:    file,
+				Message: fmt.Sprintf("content %v missing from %v", key, ContentsFile),
+			})
+		}
+	}
+
+	visuals, err := InlinePrefabs(scene.Visuals, prefabs)
+	if err != nil {
+		return append(issues, Issue{File: file, Message: err.Error()})
+	}
+
+	seen := make(map[string]string)
+	var walk func(visual *SceneVisual, parentPath string, placed bool)
+	walk = func(visual *SceneVisual, parentPath string, placed bool) {
+		path := visual.Name
+		if parentPath != "" {
+			path = parentPath + "/" + visual.Name
+		}
+
+		addIssue := func(format string, args ...any) {
+			issues = append(issues, Issue{
+				File:    file,
+				Path:    path,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		if other, found := seen[visual.Name]; found {
+			addIssue("duplicate name, also used by %v", other)
+		} else {
+			seen[visual.Name] = path
+		}
+
+		if err := validateName(visual.Name); err != nil {
+			addIssue("%v", err)
+		}
+
+		if _, alpha, err := visual.Tint(); err != nil {
+			addIssue("%v", err)
+		} else if alpha < 0 || alpha > 1 {
+			addIssue("alpha %v is not between 0 and 1", alpha)
+		}
+
+		// containers only set the position of their children
+		if placed && (visual.Transform.Anchors != mathf.SidesZero || visual.Transform.Pivot != mathf.Vec2{}) {
+			addIssue("anchors and pivot must be zero, our container places us by position")
+		}
+
+		validateVisualKind(visual, content, sceneContent, addIssue)
+		validateEvents(visual, addIssue)
+
+		kind, err := LookupVisualKind(visual.Type)
+		container := err == nil && kind.Container
+		for _, c := range visual.Children {
+			walk(c, path, container)
+		}
+	}
+
+	for _, v := range visuals {
+		walk(v, "", false)
+	}
+
+	issues = append(issues, validateTimelines(file, scene.Timelines, seen)...)
+	return append(issues, validateBreakpoints(file, scene, seen)...)
+}
+
+// validateTimelines checks our timelines can be generated as fields
+// and only animate visuals and properties that exist.
+func validateTimelines(file string, timelines []*SceneTimeline, visuals map[string]string) []Issue {
+	var issues []Issue
+
+	names := make(map[string]struct{})
+	for _, t := range timelines {
+		addIssue := func(format string, args ...any) {
+			issues = append(issues, Issue{
+				File:    file,
+				Path:    "timelines/" + t.Name,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		if _, found := names[t.Name]; found {
+			addIssue("duplicate timeline name")
+		}
+		names[t.Name] = struct{}{}
+
+		if !token.IsIdentifier(t.Name) {
+			addIssue("name %q is not a valid Go identifier", t.Name)
+		}
+
+		for _, track := range t.Tracks {
+			if _, found := visuals[track.Visual]; !found {
+				addIssue("visual %v not found", track.Visual)
+			}
+
+			if !contains(timelinePropertyOptions(), string(track.Property)) {
+				addIssue("unknown property %v of %v", track.Property, track.Visual)
+			}
+
+			if len(track.Keyframes) == 0 {
+				addIssue("%v %v has no keyframes", track.Visual, track.Property)
+			}
+
+			for i, k := range track.Keyframes {
+				if k.Time < 0 {
+					addIssue("%v %v keyframe %v has a negative time", track.Visual, track.Property, i)
+				}
+
+				if i > 0 && k.Time <= track.Keyframes[i-1].Time {
+					addIssue("%v %v keyframe %v is not after the previous keyframe", track.Visual, track.Property, i)
+				}
+
+				if _, found := components.Eases[easeName(k.Ease)]; !found {
+					addIssue("%v %v keyframe %v has unknown ease %v", track.Visual, track.Property, i, k.Ease)
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+func validateVisualKind(
+	visual *SceneVisual,
+	content map[string]Content,
+	sceneContent map[string]struct{},
+	addIssue func(format string, args ...any),
+) {
+	kind, err := LookupVisualKind(visual.Type)
+	if err != nil {
+		addIssue("%v", err)
+		return
+	}
+
+	if kind.NewData != nil && len(visual.Data) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(visual.Data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(kind.NewData()); err != nil {
+			addIssue("invalid %v data: %v", visual.Type, err)
+			return
+		}
+	}
+
+	refs, err := kind.Content(visual)
+	if err != nil {
+		addIssue("%v", err)
+		return
+	}
+
+	for _, ref := range refs {
+		validateVisualContent(ref.Key, ref.Type, content, sceneContent, addIssue)
+	}
+}
+
+func validateVisualContent(
+	key string,
+	contentType ContentType,
+	content map[string]Content,
+	sceneContent map[string]struct{},
+	addIssue func(format string, args ...any),
+) {
+	if key == "" {
+		addIssue("visual has no %v content", contentType)
+		return
+	}
+
+	c, found := content[key]
+	if !found {
+		addIssue("content %v missing from %v", key, ContentsFile)
+		return
+	}
+
+	if c.Type != contentType {
+		addIssue("content %v is %v, expected %v", key, c.Type, contentType)
+	}
+
+	if _, found := sceneContent[key]; !found {
+		addIssue("content %v not listed in scene content", key)
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// FormatIssues writes one issue per line.
+func FormatIssues(issues []Issue) string {
+	var builder strings.Builder
+	for _, i := range issues {
+		WriteFormat(&builder, "%v", i)
+	}
+
+	return builder.String()
+}
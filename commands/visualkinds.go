@@ -0,0 +1,333 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/miniscruff/igloo"
+	"github.com/miniscruff/igloo/content"
+	"github.com/miniscruff/igloo/graphics"
+	"github.com/miniscruff/inuit/components"
+)
+
+var (
+	errUnknownVisualType = errors.New("unknown visual type")
+	errWrongVisualType   = errors.New("active visual is the wrong type")
+	errContentNotFound   = errors.New("content not found")
+
+	visualKinds = make(map[VisualType]*VisualKind)
+)
+
+// ContentRef is a content key a visual uses along with the type it expects.
+type ContentRef struct {
+	Key  string
+	Type ContentType
+}
+
+// VisualKind describes a type of visual, registering a kind lets scenes,
+// the editor and generated scenes use it without changing them.
+type VisualKind struct {
+	// Type is the value of the type field in our scene visuals.
+	Type VisualType
+	// NewData returns a pointer to the data this kind keeps in the data field
+	// of a visual, it acts as the JSON schema of the kind.
+	// Built in kinds keep their data in their own fields and leave this nil.
+	NewData func() any
+	// Content lists the content keys a visual of this kind uses.
+	Content func(visual *SceneVisual) ([]ContentRef, error)
+	// New creates the editor visual, instance is the concrete visual
+	// which is kept for our commands to update.
+	New func(visual *SceneVisual, contentMap map[string]any) (instance any, vis *igloo.Visualer, err error)
+	// GoType is the type of our visual in generated trees.
+	GoType string
+	// Imports are any packages our generated code needs.
+	Imports []string
+	// Generate writes code creating the visual as name in generated trees,
+	// content is available as a variable of the same name.
+	Generate func(w io.StringWriter, name string, visual *SceneVisual) error
+	// Updates is whether the visual changes over time, generated trees
+	// call its Update method every tick.
+	Updates bool
+	// Container is whether children are placed by our visual,
+	// generated trees add them with AddChild instead of InsertChild.
+	Container bool
+	// Commands are the properties of this kind, run as: set <type> <command>
+	Commands func() []*Command
+}
+
+// RegisterVisualKind adds or replaces a visual kind,
+// kinds should be registered before creating our commands.
+func RegisterVisualKind(kind *VisualKind) {
+	visualKinds[kind.Type] = kind
+}
+
+func LookupVisualKind(visualType VisualType) (*VisualKind, error) {
+	kind, found := visualKinds[visualType]
+	if !found {
+		return nil, fmt.Errorf("%w: %v", errUnknownVisualType, visualType)
+	}
+
+	return kind, nil
+}
+
+// VisualKinds returns every registered kind sorted by type.
+func VisualKinds() []*VisualKind {
+	kinds := make([]*VisualKind, 0, len(visualKinds))
+	for _, k := range visualKinds {
+		kinds = append(kinds, k)
+	}
+
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i].Type < kinds[j].Type
+	})
+
+	return kinds
+}
+
+// DecodeData reads the data field of our visual into data.
+func (v *SceneVisual) DecodeData(data any) error {
+	if len(v.Data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(v.Data, data)
+}
+
+// EncodeData replaces the data field of our visual.
+func (v *SceneVisual) EncodeData(data any) error {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	v.Data = dataBytes
+	return nil
+}
+
+func RequiresVisualType(visualType VisualType) Validation {
+	return func(editor Editor, args []string) error {
+		if editor.Visual() == nil {
+			return errNoActiveVisual
+		}
+
+		if editor.Visual().Type != visualType {
+			return fmt.Errorf("%w: %v is not %v", errWrongVisualType, editor.Visual().Type, visualType)
+		}
+
+		return nil
+	}
+}
+
+// ContentSuggestions suggests scene content keys of a type.
+func ContentSuggestions(contentType ContentType) func(editor Editor, partial []string) []string {
+	return func(editor Editor, partial []string) []string {
+		if len(partial) != 1 {
+			return nil
+		}
+
+		var keys []string
+		for _, key := range editor.SceneData().Content {
+			if editor.ContentType(key) == contentType {
+				keys = append(keys, key)
+			}
+		}
+
+		return Filter(partial[0], keys, StringUnchanged)
+	}
+}
+
+// LookupContent finds loaded content of type T.
+func LookupContent[T any](contentMap map[string]any, key string) (T, error) {
+	c, ok := contentMap[key].(T)
+	if !ok {
+		return c, fmt.Errorf("%w: %v", errContentNotFound, key)
+	}
+
+	return c, nil
+}
+
+func init() {
+	RegisterVisualKind(emptyVisualKind())
+	RegisterVisualKind(spriteVisualKind())
+	RegisterVisualKind(labelVisualKind())
+	RegisterVisualKind(slicedSpriteVisualKind())
+	RegisterVisualKind(animatedSpriteVisualKind())
+	RegisterVisualKind(buttonVisualKind())
+	RegisterVisualKind(containerVisualKind(HBoxVisualType, components.LayoutHorizontal))
+	RegisterVisualKind(containerVisualKind(VBoxVisualType, components.LayoutVertical))
+	RegisterVisualKind(containerVisualKind(GridVisualType, components.LayoutGrid))
+}
+
+func emptyVisualKind() *VisualKind {
+	return &VisualKind{
+		Type: EmptyVisualType,
+		Content: func(visual *SceneVisual) ([]ContentRef, error) {
+			return nil, nil
+		},
+		New: func(visual *SceneVisual, contentMap map[string]any) (any, *igloo.Visualer, error) {
+			empty := graphics.NewEmptyVisual()
+			return empty, empty.Visualer, nil
+		},
+		GoType: "*graphics.EmptyVisual",
+		Generate: func(w io.StringWriter, name string, visual *SceneVisual) error {
+			WriteFormat(w, "%v := graphics.NewEmptyVisual()", name)
+			return nil
+		},
+		Commands: func() []*Command {
+			return nil
+		},
+	}
+}
+
+func spriteVisualKind() *VisualKind {
+	return &VisualKind{
+		Type: SpriteVisualType,
+		Content: func(visual *SceneVisual) ([]ContentRef, error) {
+			return []ContentRef{{Key: visual.Sprite.Content, Type: ContentSprite}}, nil
+		},
+		New: func(visual *SceneVisual, contentMap map[string]any) (any, *igloo.Visualer, error) {
+			spriteContent, err := LookupContent[*content.Sprite](contentMap, visual.Sprite.Content)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			spriteVis := graphics.NewSpriteVisual()
+			spriteVis.SetSprite(spriteContent)
+			return spriteVis, spriteVis.Visualer, nil
+		},
+		GoType: "*graphics.SpriteVisual",
+		Generate: func(w io.StringWriter, name string, visual *SceneVisual) error {
+			WriteFormat(w, "%v := graphics.NewSpriteVisual()", name)
+			WriteFormat(w, "%v.SetSprite(content.%v)", name, visual.Sprite.Content)
+			return nil
+		},
+		Commands: func() []*Command {
+			return []*Command{
+				{
+					Key: "content",
+					Help: func() string {
+						return "change the sprite content of our visual"
+					},
+					Suggestions: ContentSuggestions(ContentSprite),
+					Validations: []Validation{
+						RequiresVisualType(SpriteVisualType),
+						RequiredArgs(1),
+					},
+					Mutates: true,
+					Run: func(editor Editor, args []string) (string, error) {
+						spriteContent, err := LookupContent[*content.Sprite](editor.Content(), args[0])
+						if err != nil {
+							return "", err
+						}
+
+						editor.Visual().Sprite.Content = args[0]
+						editor.Visual().Instance.(*graphics.SpriteVisual).SetSprite(spriteContent)
+						return "", nil
+					},
+				},
+			}
+		},
+	}
+}
+
+func labelVisualKind() *VisualKind {
+	return &VisualKind{
+		Type: LabelVisualType,
+		Content: func(visual *SceneVisual) ([]ContentRef, error) {
+			return []ContentRef{{Key: visual.Label.Content, Type: ContentFont}}, nil
+		},
+		New: func(visual *SceneVisual, contentMap map[string]any) (any, *igloo.Visualer, error) {
+			fontContent, err := LookupContent[*content.Font](contentMap, visual.Label.Content)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			labelVis := graphics.NewLabelVisual()
+			labelVis.SetFont(fontContent)
+			labelVis.SetText(visual.Label.Text)
+			return labelVis, labelVis.Visualer, nil
+		},
+		GoType: "*graphics.LabelVisual",
+		Generate: func(w io.StringWriter, name string, visual *SceneVisual) error {
+			WriteFormat(w, "%v := graphics.NewLabelVisual()", name)
+			WriteFormat(w, "%v.SetFont(content.%v)", name, visual.Label.Content)
+			if visual.Label.TextKey != "" {
+				WriteFormat(w, "%v.SetText(LocalizedText(%q))", name, visual.Label.TextKey)
+			} else if visual.Label.Text != "" {
+				WriteFormat(w, "%v.SetText(%q)", name, visual.Label.Text)
+			}
+			return nil
+		},
+		Commands: func() []*Command {
+			return []*Command{
+				{
+					Key: "font",
+					Help: func() string {
+						return "change the font content of our label"
+					},
+					Suggestions: ContentSuggestions(ContentFont),
+					Validations: []Validation{
+						RequiresVisualType(LabelVisualType),
+						RequiredArgs(1),
+					},
+					Mutates: true,
+					Run: func(editor Editor, args []string) (string, error) {
+						fontContent, err := LookupContent[*content.Font](editor.Content(), args[0])
+						if err != nil {
+							return "", err
+						}
+
+						editor.Visual().Label.Content = args[0]
+						editor.Visual().Instance.(*graphics.LabelVisual).SetFont(fontContent)
+						return "", nil
+					},
+				},
+				{
+					Key: "text",
+					Help: func() string {
+						return "change the text of our label"
+					},
+					Validations: []Validation{
+						RequiresVisualType(LabelVisualType),
+					},
+					Mutates: true,
+					Run: func(editor Editor, args []string) (string, error) {
+						text := strings.Join(args, " ")
+						editor.Visual().Label.Text = text
+						editor.Visual().Label.TextKey = ""
+						editor.Visual().Instance.(*graphics.LabelVisual).SetText(text)
+						return "", nil
+					},
+				},
+				{
+					Key: "textkey",
+					Help: func() string {
+						return "show the text of a string table key in our label, none for literal text"
+					},
+					Suggestions: textKeySuggestions,
+					Validations: []Validation{
+						RequiresVisualType(LabelVisualType),
+						RequiredArgs(1),
+					},
+					Mutates: true,
+					Run: func(editor Editor, args []string) (string, error) {
+						label := editor.Visual().Instance.(*graphics.LabelVisual)
+						if args[0] == "none" {
+							editor.Visual().Label.TextKey = ""
+							label.SetText(editor.Visual().Label.Text)
+							return "", nil
+						}
+
+						editor.Visual().Label.TextKey = args[0]
+						label.SetText(LocalizedText(editor, args[0]))
+						return "", nil
+					},
+				},
+			}
+		},
+	}
+}
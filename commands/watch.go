@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os"
+	"sort"
+	"time"
+)
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+// FileWatcher polls files for changes, polling is slower than file system
+// events but works the same on every platform and editor.
+type FileWatcher struct {
+	stamps map[string]fileStamp
+}
+
+func NewFileWatcher() *FileWatcher {
+	return &FileWatcher{
+		stamps: make(map[string]fileStamp),
+	}
+}
+
+// Watch starts watching paths, or records the current state of paths
+// already watched so they are not reported as changed.
+func (w *FileWatcher) Watch(paths ...string) {
+	for _, p := range paths {
+		w.stamps[p] = stampFile(p)
+	}
+}
+
+// Reset stops watching every file.
+func (w *FileWatcher) Reset() {
+	w.stamps = make(map[string]fileStamp)
+}
+
+// Changed returns the sorted paths that changed since they were last watched
+// or reported.
+func (w *FileWatcher) Changed() []string {
+	var changed []string
+
+	for p, old := range w.stamps {
+		stamp := stampFile(p)
+		if stamp == old {
+			continue
+		}
+
+		w.stamps[p] = stamp
+		changed = append(changed, p)
+	}
+
+	sort.Strings(changed)
+	return changed
+}
+
+func stampFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+
+	return fileStamp{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		exists:  true,
+	}
+}
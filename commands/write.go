@@ -1,14 +1,43 @@
 package commands
 
+import "fmt"
+
 func writeCommand() *Command {
 	return &Command{
 		Key: "write",
 		Help: func() string {
-			return "save scene to disk"
+			return "save scene to disk, add --force to overwrite changes made on disk"
 		},
-		Run: func(editor Editor, args []string) (string, error) {
-			err := SaveSceneData(editor.SceneData(), editor.Path())
-			return "scene saved", err
+		Suggestions: func(editor Editor, partial []string) []string {
+			if len(partial) != 1 {
+				return nil
+			}
+
+			return Filter(partial[0], forceOptions, StringUnchanged)
 		},
+		Run: writeAction,
 	}
 }
+
+func writeAction(editor Editor, args []string) (string, error) {
+	force := len(args) > 0 && args[0] == forceFlag
+	if !force {
+		if err := CheckSceneFile(editor.Path(), editor.SceneFile()); err != nil {
+			diff, diffErr := diffAction(editor, nil)
+			if diffErr != nil {
+				return "", fmt.Errorf("%w, write %v to overwrite it", err, forceFlag)
+			}
+
+			return "", fmt.Errorf("%w, write %v to overwrite it with:\n%v", err, forceFlag, diff)
+		}
+	}
+
+	info, err := SaveSceneData(editor.SceneData(), editor.Path(), editor.Metadata().BackupCount())
+	if err != nil {
+		return "", err
+	}
+
+	editor.SetSceneFile(info)
+	editor.SetDirty(false)
+	return "scene saved", nil
+}
@@ -0,0 +1,132 @@
+package components
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/miniscruff/igloo/content"
+	"github.com/miniscruff/igloo/graphics"
+)
+
+type LoopMode string
+
+const (
+	AnimationLoop     LoopMode = "loop"
+	AnimationOnce     LoopMode = "once"
+	AnimationPingPong LoopMode = "pingpong"
+)
+
+var LoopModes = []LoopMode{AnimationLoop, AnimationOnce, AnimationPingPong}
+
+// AnimationFrame is a sprite shown for a duration in seconds.
+type AnimationFrame struct {
+	Sprite   *content.Sprite
+	Duration float64
+}
+
+// Animation is a list of frames played in order, an empty loop mode loops.
+type Animation struct {
+	Frames []AnimationFrame
+	Loop   LoopMode
+}
+
+// AnimatedSpriteVisual is a sprite that plays an animation,
+// Update should be called every tick to advance it.
+type AnimatedSpriteVisual struct {
+	*graphics.SpriteVisual
+
+	animation *Animation
+	frame     int
+	direction int
+	elapsed   float64
+	playing   bool
+}
+
+func NewAnimatedSpriteVisual() *AnimatedSpriteVisual {
+	return &AnimatedSpriteVisual{
+		SpriteVisual: graphics.NewSpriteVisual(),
+		direction:    1,
+		playing:      true,
+	}
+}
+
+// SetAnimation starts the animation from the first frame.
+func (a *AnimatedSpriteVisual) SetAnimation(animation *Animation) {
+	a.animation = animation
+	a.SetFrame(0)
+}
+
+// SetFrame jumps to a frame, restarting its duration.
+func (a *AnimatedSpriteVisual) SetFrame(frame int) {
+	if a.animation == nil || frame < 0 || frame >= len(a.animation.Frames) {
+		return
+	}
+
+	a.frame = frame
+	a.elapsed = 0
+	a.SetSprite(a.animation.Frames[frame].Sprite)
+}
+
+func (a *AnimatedSpriteVisual) Frame() int {
+	return a.frame
+}
+
+func (a *AnimatedSpriteVisual) Play() {
+	a.playing = true
+}
+
+func (a *AnimatedSpriteVisual) Pause() {
+	a.playing = false
+}
+
+func (a *AnimatedSpriteVisual) Playing() bool {
+	return a.playing
+}
+
+// Update advances our animation by one tick.
+func (a *AnimatedSpriteVisual) Update() {
+	a.Advance(1 / float64(ebiten.TPS()))
+}
+
+// Advance moves our animation forward by a number of seconds.
+func (a *AnimatedSpriteVisual) Advance(seconds float64) {
+	if !a.playing || a.animation == nil || len(a.animation.Frames) < 2 {
+		return
+	}
+
+	a.elapsed += seconds
+	for a.playing && a.elapsed >= a.animation.Frames[a.frame].Duration {
+		a.elapsed -= a.animation.Frames[a.frame].Duration
+		a.nextFrame()
+
+		// frames without a duration would never let us leave the loop
+		if a.animation.Frames[a.frame].Duration <= 0 {
+			a.elapsed = 0
+			break
+		}
+	}
+
+	a.SetSprite(a.animation.Frames[a.frame].Sprite)
+}
+
+func (a *AnimatedSpriteVisual) nextFrame() {
+	last := len(a.animation.Frames) - 1
+	next := a.frame + a.direction
+
+	switch a.animation.Loop {
+	case AnimationOnce:
+		if next > last {
+			next = last
+			a.playing = false
+		}
+	case AnimationPingPong:
+		if next > last || next < 0 {
+			a.direction = -a.direction
+			next = a.frame + a.direction
+		}
+	default:
+		if next > last {
+			next = 0
+		}
+	}
+
+	a.frame = next
+}
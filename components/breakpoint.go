@@ -0,0 +1,52 @@
+package components
+
+// Breakpoint overrides properties of our visuals while the window size
+// is within its bounds, a zero bound is not checked.
+type Breakpoint struct {
+	MinWidth  float64
+	MaxWidth  float64
+	MinHeight float64
+	MaxHeight float64
+	Overrides []Override
+}
+
+// Override sets a property to value while its breakpoint matches and back
+// to base otherwise.
+type Override struct {
+	Set   func(value float64)
+	Base  float64
+	Value float64
+}
+
+// Matches is whether a window size is within our bounds,
+// minimums are inclusive and maximums are exclusive.
+func (b Breakpoint) Matches(width, height float64) bool {
+	return (b.MinWidth == 0 || width >= b.MinWidth) &&
+		(b.MaxWidth == 0 || width < b.MaxWidth) &&
+		(b.MinHeight == 0 || height >= b.MinHeight) &&
+		(b.MaxHeight == 0 || height < b.MaxHeight)
+}
+
+// Breakpoints are applied in order, later breakpoints win when more than
+// one overrides the same property.
+type Breakpoints []Breakpoint
+
+// Apply resets every overridden property and then applies the overrides
+// of each breakpoint matching our window size.
+func (bs Breakpoints) Apply(width, height float64) {
+	for _, b := range bs {
+		for _, o := range b.Overrides {
+			o.Set(o.Base)
+		}
+	}
+
+	for _, b := range bs {
+		if !b.Matches(width, height) {
+			continue
+		}
+
+		for _, o := range b.Overrides {
+			o.Set(o.Value)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package components
+
+import "testing"
+
+func TestBreakpointMatches(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		breakpoint Breakpoint
+		width      float64
+		height     float64
+		matches    bool
+	}{
+		{name: "no bounds", breakpoint: Breakpoint{}, width: 800, height: 600, matches: true},
+		{name: "min width inclusive", breakpoint: Breakpoint{MinWidth: 800}, width: 800, height: 600, matches: true},
+		{name: "below min width", breakpoint: Breakpoint{MinWidth: 800}, width: 799, height: 600, matches: false},
+		{name: "max width exclusive", breakpoint: Breakpoint{MaxWidth: 800}, width: 800, height: 600, matches: false},
+		{name: "below max width", breakpoint: Breakpoint{MaxWidth: 800}, width: 799, height: 600, matches: true},
+		{name: "min height inclusive", breakpoint: Breakpoint{MinHeight: 600}, width: 800, height: 600, matches: true},
+		{name: "below min height", breakpoint: Breakpoint{MinHeight: 600}, width: 800, height: 599, matches: false},
+		{name: "max height exclusive", breakpoint: Breakpoint{MaxHeight: 600}, width: 800, height: 600, matches: false},
+		{
+			name:       "within every bound",
+			breakpoint: Breakpoint{MinWidth: 400, MaxWidth: 1000, MinHeight: 300, MaxHeight: 700},
+			width:      800,
+			height:     600,
+			matches:    true,
+		},
+		{
+			name:       "outside one bound",
+			breakpoint: Breakpoint{MinWidth: 400, MaxWidth: 1000, MinHeight: 300, MaxHeight: 500},
+			width:      800,
+			height:     600,
+			matches:    false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := tc.breakpoint.Matches(tc.width, tc.height); matches != tc.matches {
+				t.Fatalf("expected %v, got %v", tc.matches, matches)
+			}
+		})
+	}
+}
+
+func TestBreakpointsApply(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		width float64
+		value float64
+	}{
+		{name: "no match uses base", width: 300, value: 1},
+		{name: "one match", width: 500, value: 2},
+		{name: "later breakpoints win", width: 900, value: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			value := -1.0
+			set := func(v float64) { value = v }
+			breakpoints := Breakpoints{
+				{MinWidth: 400, Overrides: []Override{{Set: set, Base: 1, Value: 2}}},
+				{MinWidth: 800, Overrides: []Override{{Set: set, Base: 1, Value: 3}}},
+			}
+
+			breakpoints.Apply(tc.width, 600)
+			if value != tc.value {
+				t.Fatalf("expected %v, got %v", tc.value, value)
+			}
+		})
+	}
+}
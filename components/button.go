@@ -0,0 +1,109 @@
+package components
+
+import (
+	"github.com/miniscruff/igloo"
+	"github.com/miniscruff/igloo/content"
+	"github.com/miniscruff/igloo/graphics"
+	"github.com/miniscruff/igloo/mathf"
+)
+
+type ButtonState string
+
+const (
+	ButtonNormal  ButtonState = "normal"
+	ButtonOver    ButtonState = "over"
+	ButtonClicked ButtonState = "clicked"
+)
+
+var ButtonStates = []ButtonState{ButtonNormal, ButtonOver, ButtonClicked}
+
+// ButtonVisual is a sprite showing the mouse being over or clicking it,
+// Update should be called every tick to follow the mouse.
+type ButtonVisual struct {
+	*graphics.SpriteVisual
+	State   *igloo.FSM[ButtonState]
+	OnClick func()
+	// Label is our optional text, nil until SetLabel is called
+	Label *graphics.LabelVisual
+
+	sprites map[ButtonState]*content.Sprite
+	pointer *Pointer
+}
+
+func NewButtonVisual() *ButtonVisual {
+	b := &ButtonVisual{
+		SpriteVisual: graphics.NewSpriteVisual(),
+		State: igloo.NewFSM(
+			ButtonNormal,
+			igloo.NewFSMTransition(ButtonNormal, ButtonOver, ButtonClicked),
+			igloo.NewFSMTransition(ButtonOver, ButtonNormal, ButtonClicked),
+			igloo.NewFSMTransition(ButtonClicked, ButtonNormal, ButtonOver),
+		),
+		sprites: make(map[ButtonState]*content.Sprite),
+	}
+
+	b.pointer = &Pointer{
+		Visual: b.Visualer,
+		OnClick: func() {
+			if b.OnClick != nil {
+				b.OnClick()
+			}
+		},
+	}
+
+	return b
+}
+
+// SetStateSprite sets the sprite of a state, over and clicked show
+// the normal sprite until set.
+func (b *ButtonVisual) SetStateSprite(state ButtonState, sprite *content.Sprite) {
+	b.sprites[state] = sprite
+	b.showState()
+}
+
+// SetLabel adds or updates a label filling our button.
+func (b *ButtonVisual) SetLabel(font *content.Font, text string) {
+	if b.Label == nil {
+		b.Label = graphics.NewLabelVisual()
+		b.Label.SetAnchors(mathf.Sides{Right: 1, Bottom: 1})
+		b.InsertChild(b.Label.Visualer)
+	}
+
+	b.Label.SetFont(font)
+	b.Label.SetText(text)
+}
+
+// SetParents sets the ancestors of our button,
+// our button ignores the mouse while any of them are hidden.
+func (b *ButtonVisual) SetParents(parents ...*igloo.Visualer) {
+	b.pointer.Parents = parents
+}
+
+// Update moves between states as the mouse enters, presses and leaves
+// the layout rectangle of our button.
+func (b *ButtonVisual) Update() {
+	b.pointer.Update()
+
+	next := ButtonNormal
+	if b.pointer.Over() {
+		next = ButtonOver
+		if b.pointer.Pressed() {
+			next = ButtonClicked
+		}
+	}
+
+	if b.State.Transition(next) {
+		b.showState()
+	}
+}
+
+func (b *ButtonVisual) showState() {
+	sprite, found := b.sprites[b.State.Current()]
+	if !found || sprite == nil {
+		sprite = b.sprites[ButtonNormal]
+	}
+
+	if sprite != nil {
+		b.SpriteVisual.SetSprite(sprite)
+	}
+}
@@ -0,0 +1,193 @@
+package components
+
+import (
+	"math"
+
+	"github.com/miniscruff/igloo"
+	"github.com/miniscruff/igloo/graphics"
+	"github.com/miniscruff/igloo/mathf"
+)
+
+type LayoutDirection string
+
+const (
+	LayoutHorizontal LayoutDirection = "horizontal"
+	LayoutVertical   LayoutDirection = "vertical"
+	LayoutGrid       LayoutDirection = "grid"
+)
+
+type LayoutAlign string
+
+const (
+	AlignStart  LayoutAlign = "start"
+	AlignCenter LayoutAlign = "center"
+	AlignEnd    LayoutAlign = "end"
+)
+
+var LayoutAligns = []LayoutAlign{AlignStart, AlignCenter, AlignEnd}
+
+// ContainerVisual places its children one after another in a row, a column
+// or a grid, Update should be called every tick to follow size changes.
+// Hidden children are skipped and take no space. We only set the position
+// of our children, they should keep zero anchors and pivot and any position
+// they had is replaced.
+type ContainerVisual struct {
+	*graphics.EmptyVisual
+	Direction LayoutDirection
+	Spacing   float64
+	Padding   mathf.Sides
+	// Align places children across our direction, or within their cell in a grid
+	Align LayoutAlign
+	// Columns is the number of columns in a grid, at least one is used
+	Columns int
+
+	children []*igloo.Visualer
+	// sizes are the child and container sizes of our last layout,
+	// a change in any of them lays our children out again
+	sizes []layoutSize
+}
+
+type layoutSize struct {
+	width, height float64
+	visible       bool
+}
+
+func NewContainerVisual(direction LayoutDirection) *ContainerVisual {
+	return &ContainerVisual{
+		EmptyVisual: graphics.NewEmptyVisual(),
+		Direction:   direction,
+		Align:       AlignStart,
+		Columns:     1,
+	}
+}
+
+// AddChild inserts a child placed by our container after any existing children.
+func (c *ContainerVisual) AddChild(child *igloo.Visualer) {
+	c.InsertChild(child)
+	c.children = append(c.children, child)
+	c.Invalidate()
+}
+
+// RemoveChild detaches a child from our container,
+// the remaining children close the gap.
+func (c *ContainerVisual) RemoveChild(child *igloo.Visualer) {
+	for i, existing := range c.children {
+		if existing == child {
+			c.children = append(c.children[:i], c.children[i+1:]...)
+			c.EmptyVisual.RemoveChild(child)
+			break
+		}
+	}
+
+	c.Invalidate()
+}
+
+// Children are the children placed by our container in order.
+func (c *ContainerVisual) Children() []*igloo.Visualer {
+	return c.children
+}
+
+// Invalidate lays our children out on the next update,
+// such as after changing our spacing or padding.
+func (c *ContainerVisual) Invalidate() {
+	c.sizes = nil
+}
+
+// Update lays out our children if any of them or our container has changed size.
+func (c *ContainerVisual) Update() {
+	sizes := make([]layoutSize, 0, len(c.children)+1)
+	sizes = append(sizes, layoutSize{
+		width:  c.Transform.Width(),
+		height: c.Transform.Height(),
+	})
+
+	for _, child := range c.children {
+		sizes = append(sizes, layoutSize{
+			width:   child.Transform.Width(),
+			height:  child.Transform.Height(),
+			visible: child.Visible(),
+		})
+	}
+
+	if c.sizesEqual(sizes) {
+		return
+	}
+
+	c.sizes = sizes
+	c.LayoutChildren()
+}
+
+func (c *ContainerVisual) sizesEqual(sizes []layoutSize) bool {
+	if len(sizes) != len(c.sizes) {
+		return false
+	}
+
+	for i := range sizes {
+		if sizes[i] != c.sizes[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LayoutChildren places our children immediately.
+func (c *ContainerVisual) LayoutChildren() {
+	visible := make([]*igloo.Visualer, 0, len(c.children))
+	for _, child := range c.children {
+		if child.Visible() {
+			visible = append(visible, child)
+		}
+	}
+
+	innerWidth := c.Transform.Width() - c.Padding.Left - c.Padding.Right
+	innerHeight := c.Transform.Height() - c.Padding.Top - c.Padding.Bottom
+
+	switch c.Direction {
+	case LayoutHorizontal:
+		x := c.Padding.Left
+		for _, child := range visible {
+			child.SetX(x)
+			child.SetY(c.Padding.Top + alignOffset(c.Align, innerHeight, child.Transform.Height()))
+			x += child.Transform.Width() + c.Spacing
+		}
+	case LayoutVertical:
+		y := c.Padding.Top
+		for _, child := range visible {
+			child.SetX(c.Padding.Left + alignOffset(c.Align, innerWidth, child.Transform.Width()))
+			child.SetY(y)
+			y += child.Transform.Height() + c.Spacing
+		}
+	case LayoutGrid:
+		columns := c.Columns
+		if columns < 1 {
+			columns = 1
+		}
+
+		// every cell is as large as our largest child
+		var cellWidth, cellHeight float64
+		for _, child := range visible {
+			cellWidth = math.Max(cellWidth, child.Transform.Width())
+			cellHeight = math.Max(cellHeight, child.Transform.Height())
+		}
+
+		for i, child := range visible {
+			col := float64(i % columns)
+			row := float64(i / columns)
+			child.SetX(c.Padding.Left + col*(cellWidth+c.Spacing) + alignOffset(c.Align, cellWidth, child.Transform.Width()))
+			child.SetY(c.Padding.Top + row*(cellHeight+c.Spacing) + alignOffset(c.Align, cellHeight, child.Transform.Height()))
+		}
+	}
+}
+
+// alignOffset is how far into space a child of size is placed.
+func alignOffset(align LayoutAlign, space, size float64) float64 {
+	switch align {
+	case AlignCenter:
+		return (space - size) / 2
+	case AlignEnd:
+		return space - size
+	default:
+		return 0
+	}
+}
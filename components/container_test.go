@@ -0,0 +1,126 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/miniscruff/igloo"
+	"github.com/miniscruff/igloo/graphics"
+	"github.com/miniscruff/igloo/mathf"
+)
+
+type childSize struct {
+	width, height float64
+	hidden        bool
+}
+
+func newTestContainer(direction LayoutDirection, sizes []childSize) (*ContainerVisual, []*igloo.Visualer) {
+	container := NewContainerVisual(direction)
+	container.SetWidth(100)
+	container.SetHeight(50)
+
+	children := make([]*igloo.Visualer, 0, len(sizes))
+	for _, size := range sizes {
+		child := graphics.NewEmptyVisual().Visualer
+		child.SetWidth(size.width)
+		child.SetHeight(size.height)
+		child.SetVisible(!size.hidden)
+		container.AddChild(child)
+		children = append(children, child)
+	}
+
+	return container, children
+}
+
+func TestContainerLayoutChildren(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		direction LayoutDirection
+		spacing   float64
+		padding   mathf.Sides
+		align     LayoutAlign
+		columns   int
+		sizes     []childSize
+		positions []mathf.Vec2
+	}{
+		{
+			name:      "horizontal",
+			direction: LayoutHorizontal,
+			spacing:   5,
+			sizes:     []childSize{{width: 10, height: 10}, {width: 20, height: 30}},
+			positions: []mathf.Vec2{{X: 0, Y: 0}, {X: 15, Y: 0}},
+		},
+		{
+			name:      "horizontal with padding and center align",
+			direction: LayoutHorizontal,
+			padding:   mathf.Sides{Left: 2, Top: 4, Bottom: 6},
+			align:     AlignCenter,
+			sizes:     []childSize{{width: 10, height: 10}, {width: 20, height: 30}},
+			positions: []mathf.Vec2{{X: 2, Y: 19}, {X: 12, Y: 9}},
+		},
+		{
+			name:      "vertical end align",
+			direction: LayoutVertical,
+			spacing:   1,
+			align:     AlignEnd,
+			sizes:     []childSize{{width: 10, height: 10}, {width: 20, height: 30}},
+			positions: []mathf.Vec2{{X: 90, Y: 0}, {X: 80, Y: 11}},
+		},
+		{
+			name:      "hidden children take no space",
+			direction: LayoutVertical,
+			sizes:     []childSize{{width: 10, height: 10}, {width: 10, height: 10, hidden: true}, {width: 10, height: 10}},
+			positions: []mathf.Vec2{{X: 0, Y: 0}, {X: 0, Y: 0}, {X: 0, Y: 10}},
+		},
+		{
+			name:      "grid cells fit our largest child",
+			direction: LayoutGrid,
+			spacing:   2,
+			columns:   2,
+			sizes:     []childSize{{width: 10, height: 10}, {width: 20, height: 5}, {width: 10, height: 10}},
+			positions: []mathf.Vec2{{X: 0, Y: 0}, {X: 22, Y: 0}, {X: 0, Y: 12}},
+		},
+		{
+			name:      "grid without columns uses one",
+			direction: LayoutGrid,
+			sizes:     []childSize{{width: 10, height: 10}, {width: 10, height: 10}},
+			positions: []mathf.Vec2{{X: 0, Y: 0}, {X: 0, Y: 10}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			container, children := newTestContainer(tc.direction, tc.sizes)
+			container.Spacing = tc.spacing
+			container.Padding = tc.padding
+			container.Columns = tc.columns
+			if tc.align != "" {
+				container.Align = tc.align
+			}
+
+			container.LayoutChildren()
+			for i, child := range children {
+				position := mathf.Vec2{X: child.Transform.X(), Y: child.Transform.Y()}
+				if position != tc.positions[i] {
+					t.Errorf("expected child %v at %v, got %v", i, tc.positions[i], position)
+				}
+			}
+		})
+	}
+}
+
+func TestContainerRemoveChild(t *testing.T) {
+	container, children := newTestContainer(LayoutHorizontal, []childSize{
+		{width: 10, height: 10},
+		{width: 20, height: 10},
+		{width: 30, height: 10},
+	})
+
+	container.RemoveChild(children[1])
+	container.Update()
+
+	if len(container.Children()) != 2 {
+		t.Fatalf("expected 2 children, got %v", len(container.Children()))
+	}
+
+	if x := children[2].Transform.X(); x != 10 {
+		t.Fatalf("expected our last child to close the gap at 10, got %v", x)
+	}
+}
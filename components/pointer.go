@@ -0,0 +1,80 @@
+package components
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/miniscruff/igloo"
+	"github.com/miniscruff/igloo/mathf"
+)
+
+// Pointer calls handlers as the mouse moves over and clicks a visual,
+// a click is a press and release of the left button both over our visual.
+type Pointer struct {
+	Visual *igloo.Visualer
+	// Parents are the ancestors of our visual, hiding one hides our visual too
+	Parents []*igloo.Visualer
+	OnClick func()
+	OnEnter func()
+	OnLeave func()
+
+	over    bool
+	pressed bool
+}
+
+// Over is whether the mouse is over our visual as of the last update.
+func (p *Pointer) Over() bool {
+	return p.over
+}
+
+// Pressed is whether a click started over our visual and is still held.
+func (p *Pointer) Pressed() bool {
+	return p.pressed
+}
+
+// Visible is whether our visual and each of its parents are visible.
+func (p *Pointer) Visible() bool {
+	if !p.Visual.Visible() {
+		return false
+	}
+
+	for _, parent := range p.Parents {
+		if !parent.Visible() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Update checks the mouse against the layout rectangle of our visual,
+// our visual should be laid out before updating.
+func (p *Pointer) Update() {
+	wasOver := p.over
+	p.over = p.Visible() && ContainsCursor(p.Visual)
+
+	if p.over && !wasOver && p.OnEnter != nil {
+		p.OnEnter()
+	}
+	if !p.over && wasOver && p.OnLeave != nil {
+		p.OnLeave()
+	}
+
+	if p.over && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		p.pressed = true
+	}
+
+	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		clicked := p.pressed && p.over
+		p.pressed = false
+
+		if clicked && p.OnClick != nil {
+			p.OnClick()
+		}
+	}
+}
+
+// ContainsCursor is whether the mouse is within the layout rectangle of a visual.
+func ContainsCursor(vis *igloo.Visualer) bool {
+	x, y := ebiten.CursorPosition()
+	return vis.Transform.Bounds().Contains(mathf.Vec2{X: float64(x), Y: float64(y)})
+}
@@ -0,0 +1,96 @@
+package components
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/miniscruff/igloo/content"
+	"github.com/miniscruff/igloo/graphics"
+	"github.com/miniscruff/igloo/mathf"
+)
+
+// SlicedSprite is an image split into nine parts by its borders,
+// the corners keep their size while the edges and center stretch.
+type SlicedSprite struct {
+	Image   *ebiten.Image
+	Borders mathf.Sides
+}
+
+// SlicedSpriteVisual draws a sliced sprite stretched to fit its transform,
+// each part is a sprite child anchored to its side of our visual.
+type SlicedSpriteVisual struct {
+	*graphics.EmptyVisual
+
+	parts []*graphics.SpriteVisual
+}
+
+func NewSlicedSpriteVisual() *SlicedSpriteVisual {
+	s := &SlicedSpriteVisual{
+		EmptyVisual: graphics.NewEmptyVisual(),
+	}
+
+	for i := 0; i < 9; i++ {
+		part := graphics.NewSpriteVisual()
+		part.SetVisible(true)
+		s.InsertChild(part.Visualer)
+		s.parts = append(s.parts, part)
+	}
+
+	return s
+}
+
+func (s *SlicedSpriteVisual) SetSlicedSprite(sliced *SlicedSprite) {
+	bounds := sliced.Image.Bounds()
+	borders := sliced.Borders
+
+	xs := []int{
+		bounds.Min.X,
+		bounds.Min.X + int(borders.Left),
+		bounds.Max.X - int(borders.Right),
+		bounds.Max.X,
+	}
+	ys := []int{
+		bounds.Min.Y,
+		bounds.Min.Y + int(borders.Top),
+		bounds.Max.Y - int(borders.Bottom),
+		bounds.Max.Y,
+	}
+
+	// start and end anchors and offsets of each column or row,
+	// the first is pinned to the start, the middle stretches and the last
+	// is pinned to the end
+	anchors := [][]float64{{0, 0}, {0, 1}, {1, 1}}
+	xOffsets := [][]float64{
+		{0, borders.Left},
+		{borders.Left, -borders.Right},
+		{-borders.Right, 0},
+	}
+	yOffsets := [][]float64{
+		{0, borders.Top},
+		{borders.Top, -borders.Bottom},
+		{-borders.Bottom, 0},
+	}
+
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			part := s.parts[row*3+col]
+			rect := image.Rect(xs[col], ys[row], xs[col+1], ys[row+1])
+
+			part.SetSprite(&content.Sprite{
+				Image: sliced.Image.SubImage(rect).(*ebiten.Image),
+			})
+			part.SetAnchors(mathf.Sides{
+				Left:   anchors[col][0],
+				Right:  anchors[col][1],
+				Top:    anchors[row][0],
+				Bottom: anchors[row][1],
+			})
+			part.SetOffsets(mathf.Sides{
+				Left:   xOffsets[col][0],
+				Right:  xOffsets[col][1],
+				Top:    yOffsets[row][0],
+				Bottom: yOffsets[row][1],
+			})
+		}
+	}
+}
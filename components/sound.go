@@ -0,0 +1,107 @@
+package components
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+var errNoSoundAssets = errors.New("sound assets are not set, call components.SetSoundAssets")
+
+// soundFS and soundAssetsPath are where LoadSound opens sound files.
+var (
+	soundFS         fs.FS
+	soundAssetsPath string
+)
+
+// SampleRate is used by every sound, ebiten only allows one audio context.
+const SampleRate = 44100
+
+// AudioContext returns the audio context, creating it on first use.
+func AudioContext() *audio.Context {
+	if context := audio.CurrentContext(); context != nil {
+		return context
+	}
+
+	return audio.NewContext(SampleRate)
+}
+
+// Sound is decoded audio that can be played any number of times at once.
+type Sound struct {
+	pcm []byte
+}
+
+// DecodeSound decodes audio with one of ebiten's decoders,
+// such as wav.DecodeWithSampleRate.
+func DecodeSound[S io.Reader](
+	src io.Reader,
+	decode func(sampleRate int, src io.Reader) (S, error),
+) (*Sound, error) {
+	stream, err := decode(SampleRate, src)
+	if err != nil {
+		return nil, err
+	}
+
+	pcm, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sound{pcm: pcm}, nil
+}
+
+// SetSoundAssets sets where LoadSound opens sound files, games pass the same
+// file system and assets path as their igloo.GameConfig so sounds load from
+// the same place as images and fonts.
+func SetSoundAssets(fsys fs.FS, assetsPath string) {
+	soundFS = fsys
+	soundAssetsPath = assetsPath
+}
+
+// LoadSound opens and decodes a sound file relative to our sound assets path.
+func LoadSound[S io.Reader](
+	file string,
+	decode func(sampleRate int, src io.Reader) (S, error),
+) (*Sound, error) {
+	if soundFS == nil {
+		return nil, errNoSoundAssets
+	}
+
+	src, err := soundFS.Open(path.Join(soundAssetsPath, file))
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return DecodeSound(src, decode)
+}
+
+// Play starts a new player of our sound from the beginning.
+func (s *Sound) Play() *audio.Player {
+	player := AudioContext().NewPlayerFromBytes(s.pcm)
+	player.Play()
+
+	return player
+}
+
+// Loop starts a new player that repeats our sound until it is paused,
+// useful for music and ambience.
+func (s *Sound) Loop() (*audio.Player, error) {
+	loop := audio.NewInfiniteLoop(bytes.NewReader(s.pcm), int64(len(s.pcm)))
+	player, err := AudioContext().NewPlayer(loop)
+	if err != nil {
+		return nil, err
+	}
+
+	player.Play()
+	return player, nil
+}
+
+// Dispose releases our decoded samples, players already started keep playing.
+func (s *Sound) Dispose() {
+	s.pcm = nil
+}
@@ -0,0 +1,262 @@
+package components
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/miniscruff/igloo"
+	"github.com/miniscruff/igloo/mathf"
+)
+
+type TimelineProperty string
+
+const (
+	PropertyX        TimelineProperty = "x"
+	PropertyY        TimelineProperty = "y"
+	PropertyRotation TimelineProperty = "rotation"
+	PropertyAlpha    TimelineProperty = "alpha"
+	PropertyScaleX   TimelineProperty = "scaleX"
+	PropertyScaleY   TimelineProperty = "scaleY"
+	PropertyWidth    TimelineProperty = "width"
+	PropertyHeight   TimelineProperty = "height"
+	PropertyPivotX   TimelineProperty = "pivotX"
+	PropertyPivotY   TimelineProperty = "pivotY"
+
+	PropertyAnchorLeft   TimelineProperty = "anchorLeft"
+	PropertyAnchorRight  TimelineProperty = "anchorRight"
+	PropertyAnchorTop    TimelineProperty = "anchorTop"
+	PropertyAnchorBottom TimelineProperty = "anchorBottom"
+	PropertyOffsetLeft   TimelineProperty = "offsetLeft"
+	PropertyOffsetRight  TimelineProperty = "offsetRight"
+	PropertyOffsetTop    TimelineProperty = "offsetTop"
+	PropertyOffsetBottom TimelineProperty = "offsetBottom"
+)
+
+// TimelineProperties are the properties timelines animate and breakpoints
+// override. Size, pivot, anchors and offsets exist for breakpoints to adjust
+// layouts but timelines can animate them as well.
+var TimelineProperties = []TimelineProperty{
+	PropertyX,
+	PropertyY,
+	PropertyRotation,
+	PropertyAlpha,
+	PropertyScaleX,
+	PropertyScaleY,
+	PropertyWidth,
+	PropertyHeight,
+	PropertyPivotX,
+	PropertyPivotY,
+	PropertyAnchorLeft,
+	PropertyAnchorRight,
+	PropertyAnchorTop,
+	PropertyAnchorBottom,
+	PropertyOffsetLeft,
+	PropertyOffsetRight,
+	PropertyOffsetTop,
+	PropertyOffsetBottom,
+}
+
+// Eases are the easing curves keyframes can use by name,
+// each is the mathf function of the same name prefixed with Ease.
+var Eases = map[string]mathf.EaseFunc{
+	"Linear":       mathf.EaseLinear,
+	"InQuad":       mathf.EaseInQuad,
+	"OutQuad":      mathf.EaseOutQuad,
+	"InOutQuad":    mathf.EaseInOutQuad,
+	"InCubic":      mathf.EaseInCubic,
+	"OutCubic":     mathf.EaseOutCubic,
+	"InOutCubic":   mathf.EaseInOutCubic,
+	"InSine":       mathf.EaseInSine,
+	"OutSine":      mathf.EaseOutSine,
+	"InOutSine":    mathf.EaseInOutSine,
+	"InBack":       mathf.EaseInBack,
+	"OutBack":      mathf.EaseOutBack,
+	"InOutBack":    mathf.EaseInOutBack,
+	"InBounce":     mathf.EaseInBounce,
+	"OutBounce":    mathf.EaseOutBounce,
+	"InOutBounce":  mathf.EaseInOutBounce,
+	"InElastic":    mathf.EaseInElastic,
+	"OutElastic":   mathf.EaseOutElastic,
+	"InOutElastic": mathf.EaseInOutElastic,
+}
+
+// Keyframe is the value of a track at a time in seconds,
+// ease shapes the change from the previous keyframe, nil is linear.
+type Keyframe struct {
+	Time  float64
+	Value float64
+	Ease  mathf.EaseFunc
+}
+
+// TimelineTrack sets one property from keyframes sorted by time.
+type TimelineTrack struct {
+	Keyframes []Keyframe
+	Set       func(value float64)
+}
+
+// Value is our track at a time, holding the first and last keyframes
+// before and after the track.
+func (t *TimelineTrack) Value(time float64) float64 {
+	if len(t.Keyframes) == 0 {
+		return 0
+	}
+
+	if time <= t.Keyframes[0].Time {
+		return t.Keyframes[0].Value
+	}
+
+	for i := 1; i < len(t.Keyframes); i++ {
+		to := t.Keyframes[i]
+		if time >= to.Time {
+			continue
+		}
+
+		from := t.Keyframes[i-1]
+		progress := (time - from.Time) / (to.Time - from.Time)
+		if to.Ease != nil {
+			progress = to.Ease(progress)
+		}
+
+		return from.Value + (to.Value-from.Value)*progress
+	}
+
+	return t.Keyframes[len(t.Keyframes)-1].Value
+}
+
+// Timeline plays keyframe tracks together, OnComplete is called when
+// a timeline that does not loop reaches its end.
+type Timeline struct {
+	Tracks     []TimelineTrack
+	Loop       bool
+	OnComplete func()
+
+	time    float64
+	playing bool
+}
+
+// Duration is the time of our last keyframe.
+func (t *Timeline) Duration() float64 {
+	var duration float64
+	for _, track := range t.Tracks {
+		if len(track.Keyframes) > 0 {
+			duration = math.Max(duration, track.Keyframes[len(track.Keyframes)-1].Time)
+		}
+	}
+
+	return duration
+}
+
+// Play starts our timeline from the beginning.
+func (t *Timeline) Play() {
+	t.playing = true
+	t.Seek(0)
+}
+
+func (t *Timeline) Pause() {
+	t.playing = false
+}
+
+func (t *Timeline) Resume() {
+	t.playing = true
+}
+
+func (t *Timeline) Playing() bool {
+	return t.playing
+}
+
+func (t *Timeline) Time() float64 {
+	return t.time
+}
+
+// Seek moves to a time and sets every track to its value.
+func (t *Timeline) Seek(time float64) {
+	t.time = time
+	for i := range t.Tracks {
+		t.Tracks[i].Set(t.Tracks[i].Value(time))
+	}
+}
+
+// Update advances our timeline by one tick.
+func (t *Timeline) Update() {
+	t.Advance(1 / float64(ebiten.TPS()))
+}
+
+// Advance moves our timeline forward by a number of seconds.
+func (t *Timeline) Advance(seconds float64) {
+	if !t.playing {
+		return
+	}
+
+	time := t.time + seconds
+	duration := t.Duration()
+	if time < duration {
+		t.Seek(time)
+		return
+	}
+
+	if t.Loop && duration > 0 {
+		t.Seek(math.Mod(time, duration))
+		return
+	}
+
+	t.playing = false
+	t.Seek(duration)
+	if t.OnComplete != nil {
+		t.OnComplete()
+	}
+}
+
+// TrackSetter sets a property of a visual, alpha replaces the opacity
+// of any tint while keeping its color.
+func TrackSetter(vis *igloo.Visualer, property TimelineProperty) func(value float64) {
+	switch property {
+	case PropertyX:
+		return vis.SetX
+	case PropertyY:
+		return vis.SetY
+	case PropertyRotation:
+		return vis.SetRotation
+	case PropertyAlpha:
+		return func(value float64) {
+			vis.ColorM.SetElement(3, 3, value)
+		}
+	case PropertyScaleX:
+		return func(value float64) {
+			scale := vis.Transform.Scale()
+			scale.X = value
+			vis.SetScale(scale)
+		}
+	case PropertyScaleY:
+		return func(value float64) {
+			scale := vis.Transform.Scale()
+			scale.Y = value
+			vis.SetScale(scale)
+		}
+	case PropertyWidth:
+		return vis.SetWidth
+	case PropertyHeight:
+		return vis.SetHeight
+	case PropertyPivotX:
+		return vis.Transform.SetPivotX
+	case PropertyPivotY:
+		return vis.Transform.SetPivotY
+	case PropertyAnchorLeft:
+		return vis.Transform.SetLeftAnchor
+	case PropertyAnchorRight:
+		return vis.Transform.SetRightAnchor
+	case PropertyAnchorTop:
+		return vis.Transform.SetTopAnchor
+	case PropertyAnchorBottom:
+		return vis.Transform.SetBottomAnchor
+	case PropertyOffsetLeft:
+		return vis.Transform.SetLeftOffset
+	case PropertyOffsetRight:
+		return vis.Transform.SetRightOffset
+	case PropertyOffsetTop:
+		return vis.Transform.SetTopOffset
+	case PropertyOffsetBottom:
+		return vis.Transform.SetBottomOffset
+	default:
+		return func(value float64) {}
+	}
+}
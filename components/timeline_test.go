@@ -0,0 +1,128 @@
+package components
+
+import (
+	"math"
+	"testing"
+
+	"github.com/miniscruff/igloo/mathf"
+)
+
+func TestTimelineTrackValue(t *testing.T) {
+	track := TimelineTrack{
+		Keyframes: []Keyframe{
+			{Time: 1, Value: 10},
+			{Time: 3, Value: 30},
+			{Time: 4, Value: 0, Ease: mathf.EaseInQuad},
+		},
+	}
+
+	for _, tc := range []struct {
+		name  string
+		track TimelineTrack
+		time  float64
+		value float64
+	}{
+		{name: "no keyframes", track: TimelineTrack{}, time: 1, value: 0},
+		{name: "before first keyframe", track: track, time: 0, value: 10},
+		{name: "on first keyframe", track: track, time: 1, value: 10},
+		{name: "linear between keyframes", track: track, time: 2, value: 20},
+		{name: "on middle keyframe", track: track, time: 3, value: 30},
+		{name: "eased between keyframes", track: track, time: 3.5, value: 30 - 30*mathf.EaseInQuad(0.5)},
+		{name: "on last keyframe", track: track, time: 4, value: 0},
+		{name: "after last keyframe", track: track, time: 10, value: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if value := tc.track.Value(tc.time); math.Abs(value-tc.value) > 1e-9 {
+				t.Fatalf("expected %v, got %v", tc.value, value)
+			}
+		})
+	}
+}
+
+func TestTimelineAdvance(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		loop      bool
+		paused    bool
+		advance   []float64
+		time      float64
+		value     float64
+		playing   bool
+		completed int
+	}{
+		{
+			name:    "within duration",
+			advance: []float64{0.5},
+			time:    0.5,
+			value:   5,
+			playing: true,
+		},
+		{
+			name:      "reaching the end completes",
+			advance:   []float64{0.5, 0.75},
+			time:      1,
+			value:     10,
+			completed: 1,
+		},
+		{
+			name:      "after completing we stay put",
+			advance:   []float64{2, 1},
+			time:      1,
+			value:     10,
+			completed: 1,
+		},
+		{
+			name:    "looping wraps around",
+			loop:    true,
+			advance: []float64{0.5, 0.75},
+			time:    0.25,
+			value:   2.5,
+			playing: true,
+		},
+		{
+			name:    "paused does not move",
+			paused:  true,
+			advance: []float64{0.5},
+			time:    0,
+			value:   0,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var value float64
+			completed := 0
+			timeline := &Timeline{
+				Tracks: []TimelineTrack{{
+					Keyframes: []Keyframe{{Time: 0, Value: 0}, {Time: 1, Value: 10}},
+					Set:       func(v float64) { value = v },
+				}},
+				Loop:       tc.loop,
+				OnComplete: func() { completed++ },
+			}
+
+			timeline.Play()
+			if tc.paused {
+				timeline.Pause()
+			}
+
+			for _, seconds := range tc.advance {
+				timeline.Advance(seconds)
+			}
+
+			if math.Abs(timeline.Time()-tc.time) > 1e-9 {
+				t.Errorf("expected time %v, got %v", tc.time, timeline.Time())
+			}
+
+			if math.Abs(value-tc.value) > 1e-9 {
+				t.Errorf("expected value %v, got %v", tc.value, value)
+			}
+
+			if timeline.Playing() != tc.playing {
+				t.Errorf("expected playing %v, got %v", tc.playing, timeline.Playing())
+			}
+
+			if completed != tc.completed {
+				t.Errorf("expected %v completions, got %v", tc.completed, completed)
+			}
+		})
+	}
+}
@@ -7,12 +7,14 @@ import (
 
 	"embed"
 	"fmt"
+	"os"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/miniscruff/igloo"
 
 	"github.com/miniscruff/inuit/scenes"
 	"github.com/miniscruff/inuit/commands"
+	"github.com/miniscruff/inuit/components"
 )
 
 var (
@@ -21,6 +23,14 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Exit(validate())
+	}
+
+	if len(os.Args) > 3 && os.Args[1] == "asset" && os.Args[2] == "import-pxo" {
+		os.Exit(importPxo(os.Args[3:]))
+	}
+
 	// slightly better random seed
 	var b [8]byte
 	_, err := crypto_rand.Read(b[:])
@@ -30,10 +40,12 @@ func main() {
 	math_rand.Seed(int64(binary.LittleEndian.Uint64(b[:])))
 
 	// initialize our game and window state
-	igloo.InitGame(igloo.GameConfig{
+	gameConfig := igloo.GameConfig{
 		Fsys: assetsFS,
 		AssetsPath: "assets",
-	})
+	}
+	igloo.InitGame(gameConfig)
+	components.SetSoundAssets(gameConfig.Fsys, gameConfig.AssetsPath)
 	igloo.SetWindowSize(1024, 768)
 	igloo.SetScreenSize(1024, 768)
 	ebiten.SetWindowTitle("inuit")
@@ -51,3 +63,38 @@ func main() {
 		fmt.Printf("run complete: %v\n", err)
 	}
 }
+
+// validate prints any issues in our project and returns the exit code
+func validate() int {
+	issues, err := commands.ValidateProject(nil)
+	if err != nil {
+		fmt.Printf("unable to validate project: %v\n", err)
+		return 1
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("no issues found")
+		return 0
+	}
+
+	fmt.Print(commands.FormatIssues(issues))
+	return 1
+}
+
+// importPxo imports a pixelorama project and returns the exit code
+func importPxo(args []string) int {
+	var metadata commands.Metadata
+	if err := commands.LoadMetadata(&metadata); err != nil {
+		fmt.Printf("unable to load metadata: %v\n", err)
+		return 1
+	}
+
+	output, err := commands.ImportPxoAction(metadata, args)
+	if err != nil {
+		fmt.Printf("unable to import: %v\n", err)
+		return 1
+	}
+
+	fmt.Println(output)
+	return 0
+}
@@ -18,4 +18,5 @@ func (s *DemoScene) PostSetup() error {
 }
 
 func (s *DemoScene) Update() {
+	s.tree.Update()
 }
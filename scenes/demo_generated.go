@@ -129,10 +129,17 @@ func NewDemoContent(assets *DemoAssets) (*DemoContent, error) {
 }
 
 type DemoTree struct {
-	World      *graphics.EmptyVisual
-	TopLeft    *graphics.SpriteVisual
-	MidCenter  *graphics.SpriteVisual
-	FullScreen *graphics.SpriteVisual
+	World        *graphics.EmptyVisual
+	TopLeft      *graphics.SpriteVisual
+	MidCenter    *graphics.SpriteVisual
+	FullScreen   *graphics.SpriteVisual
+	Timelines    *DemoTimelines
+	windowWidth  float64
+	windowHeight float64
+	onResize     func(width, height float64)
+}
+
+type DemoTimelines struct {
 }
 
 func NewDemoTree(content *DemoContent) (*DemoTree, error) {
@@ -166,13 +173,48 @@ func NewDemoTree(content *DemoContent) (*DemoTree, error) {
 	World.InsertChild(FullScreen.Visualer)
 
 	return &DemoTree{
-		World:      World,
-		TopLeft:    TopLeft,
-		MidCenter:  MidCenter,
-		FullScreen: FullScreen,
+		World:        World,
+		TopLeft:      TopLeft,
+		MidCenter:    MidCenter,
+		FullScreen:   FullScreen,
+		Timelines:    &DemoTimelines{},
+		windowWidth:  windowWidth,
+		windowHeight: windowHeight,
 	}, nil
 }
 
+// Update advances any visuals and timelines that change over time.
+func (t *DemoTree) Update() {
+}
+
+// Resize sizes our window sized visuals and applies our breakpoints
+// for a new window size, drawing resizes automatically.
+func (t *DemoTree) Resize(width, height float64) {
+	t.windowWidth = width
+	t.windowHeight = height
+	t.World.Transform.SetWidth(width)
+	t.World.Transform.SetHeight(height)
+
+	if t.onResize != nil {
+		t.onResize(width, height)
+	}
+}
+
+// ByTag returns every visual with a tag in tree order.
+func (t *DemoTree) ByTag(tag string) []*igloo.Visualer {
+	switch tag {
+	}
+
+	return nil
+}
+
+// Props returns the props of a visual by name.
+func (t *DemoTree) Props(name string) map[string]string {
+	return demoProps[name]
+}
+
+var demoProps = map[string]map[string]string{}
+
 func (s *DemoScene) Setup(assetLoader *igloo.AssetLoader) error {
 	var err error
 
@@ -191,10 +233,19 @@ func (s *DemoScene) Setup(assetLoader *igloo.AssetLoader) error {
 		return err
 	}
 
+	// our base scene can optionally follow window size changes
+	if resizer, ok := any(s).(interface{ OnResize(width, height float64) }); ok {
+		s.tree.onResize = resizer.OnResize
+	}
+
 	return nil
 }
 
 func (s *DemoScene) Draw(dest *ebiten.Image) {
+	// follow window size changes before laying out our visuals
+	if ww, wh := igloo.GetWindowSize(); float64(ww) != s.tree.windowWidth || float64(wh) != s.tree.windowHeight {
+		s.tree.Resize(float64(ww), float64(wh))
+	}
 	s.tree.World.Visualer.Layout(s.tree.World.Transform, nil)
 	s.tree.World.Visualer.Draw(dest)
 }
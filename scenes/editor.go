@@ -3,39 +3,69 @@ package scenes
 import (
 	"fmt"
 	"image/color"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/miniscruff/igloo"
 	"github.com/miniscruff/igloo/content"
 	"github.com/miniscruff/igloo/graphics"
 	"github.com/miniscruff/igloo/mathf"
 	"github.com/miniscruff/inuit/commands"
 	"github.com/miniscruff/inuit/components"
+	"golang.org/x/image/font/opentype"
 )
 
-const wasdSpeed = 5
+const (
+	wasdSpeed     = 5
+	watchInterval = time.Second
+)
 
 type EditorScene struct {
-	assets          *EditorAssets
-	content         *EditorContent
-	disposeHandlers []func()
-	sceneData       commands.SceneData
+	assets    *EditorAssets
+	content   *EditorContent
+	sceneData commands.SceneData
+	sceneFile commands.SceneFileInfo
+	metadata  commands.Metadata
 
 	sceneAssets  map[string]any
 	sceneContent map[string]any
+	assetData    map[string]commands.Asset
+	contentData  map[string]commands.Content
+	stringTables commands.StringTables
+	locale       string
+	// previewWidth and previewHeight replace our window size when set
+	previewWidth  float64
+	previewHeight float64
 
 	path     string
 	commands *commands.Commands
 
+	dirty       bool
+	confirmQuit bool
+	lastSave    time.Time
+	watcher     *commands.FileWatcher
+	// assetWatcher tells ReloadContent which asset files to load again
+	assetWatcher *commands.FileWatcher
+	lastWatch    time.Time
+
 	activeVisual *commands.SceneVisual
+	overlay      editorOverlay
 	offset       *mathf.Transform
+	timeline     *components.Timeline
 
 	commandInput     *components.TextEditor
 	inputRoot        *graphics.EmptyVisual
 	textInputLabel   *graphics.LabelVisual
 	inputResponse    *graphics.LabelVisual
 	suggestionsLabel *graphics.LabelVisual
+	statusRoot       *graphics.EmptyVisual
+	statusLabel      *graphics.LabelVisual
 }
 
 func NewEditorScene(path string) *EditorScene {
@@ -58,61 +88,12 @@ func (s *EditorScene) Setup(assetLoader *igloo.AssetLoader) (err error) {
 		return err
 	}
 
-	var (
-		assetData    map[string]commands.Asset
-		contentData  map[string]commands.Content
-		metadataData commands.Metadata
-	)
-
-	if err := commands.LoadAssets(&assetData); err != nil {
-		return err
-	}
-	if err := commands.LoadContent(&contentData); err != nil {
-		return err
-	}
-	if err := commands.LoadMetadata(&metadataData); err != nil {
+	s.watcher = commands.NewFileWatcher()
+	s.assetWatcher = commands.NewFileWatcher()
+	if err := s.loadProject(); err != nil {
 		return err
 	}
 
-	if err := commands.LoadSceneData(&s.sceneData, s.path); err != nil {
-		return fmt.Errorf("unable to load scene data: %w", err)
-	}
-
-	s.sceneAssets = make(map[string]any)
-	s.sceneContent = make(map[string]any)
-
-	for k, a := range assetData {
-		switch a.Type {
-		case commands.AssetImage:
-			// TODO: can not use the asset loader when loading an external asset
-			img, err := assetLoader.LoadImage(a.File)
-			if err != nil {
-				return err
-			}
-
-			s.sceneAssets[k] = img
-			s.disposeHandlers = append(s.disposeHandlers, func() {
-				img.Dispose()
-			})
-		}
-	}
-
-	for k, c := range contentData {
-		switch c.Type {
-		case commands.ContentSprite:
-			sprite := &content.Sprite{
-				Image: s.sceneAssets[c.Sprite.Asset].(*ebiten.Image),
-				// TODO: other sprite attributes
-			}
-
-			s.sceneContent[k] = sprite
-		}
-	}
-
-	for _, t := range s.sceneData.Visuals {
-		loadVisual(t, s.sceneContent, nil)
-	}
-
 	ww, wh := igloo.GetWindowSize()
 	windowWidth := float64(ww)
 	windowHeight := float64(wh)
@@ -138,6 +119,21 @@ func (s *EditorScene) Setup(assetLoader *igloo.AssetLoader) (err error) {
 	s.suggestionsLabel.Transform.SetPosition(mathf.Vec2{X: 5, Y: -35})
 	s.suggestionsLabel.SetVisible(true)
 
+	s.statusLabel = graphics.NewLabelVisual()
+	s.statusLabel.SetFont(s.content.SonoRegular18)
+	s.statusLabel.ColorM.ScaleWithColor(color.White)
+	s.statusLabel.Transform.SetPivot(mathf.Vec2{X: 1, Y: 0})
+	s.statusLabel.Transform.SetAnchors(mathf.Sides{Left: 1, Right: 1})
+	s.statusLabel.Transform.SetPosition(mathf.Vec2{X: -5, Y: 5})
+	s.statusLabel.SetVisible(true)
+
+	s.statusRoot = graphics.NewEmptyVisual()
+	s.statusRoot.Transform.SetWidth(windowWidth)
+	s.statusRoot.Transform.SetHeight(windowHeight)
+	s.statusRoot.SetVisible(true)
+	s.statusRoot.InsertChild(s.statusLabel.Visualer)
+	s.updateStatus()
+
 	s.inputRoot = graphics.NewEmptyVisual()
 	s.inputRoot.Transform.SetWidth(windowWidth)
 	s.inputRoot.Transform.SetHeight(windowHeight)
@@ -161,6 +157,13 @@ func (s *EditorScene) Setup(assetLoader *igloo.AssetLoader) (err error) {
 	suggestionBackground.SetVisible(true)
 	s.suggestionsLabel.InsertChild(suggestionBackground.Visualer)
 
+	statusBackground := graphics.NewSpriteVisual()
+	statusBackground.SetSprite(s.content.NormalBackground)
+	statusBackground.SetAnchors(mathf.SidesStretchBoth)
+	statusBackground.SetOffsets(mathf.Sides{Left: -5, Right: -5, Top: -5, Bottom: -5})
+	statusBackground.SetVisible(true)
+	s.statusLabel.InsertChild(statusBackground.Visualer)
+
 	responseBackground := graphics.NewSpriteVisual()
 	responseBackground.SetSprite(s.content.NormalBackground)
 	responseBackground.SetAnchors(mathf.SidesStretchBoth)
@@ -200,6 +203,7 @@ func (s *EditorScene) Setup(assetLoader *igloo.AssetLoader) (err error) {
 		return strings.Join(split[:len(split)-1], " ") + " " + suggestions[0] + " "
 	}
 	s.commandInput.Submit = func(text string) {
+		s.confirmQuit = false
 		output, err := s.commands.Run(text)
 		if err != nil {
 			output = fmt.Sprintf("unable to run command: %v\n%v", text, err.Error())
@@ -208,33 +212,541 @@ func (s *EditorScene) Setup(assetLoader *igloo.AssetLoader) (err error) {
 		s.inputResponse.SetText(output)
 	}
 
+	s.lastSave = time.Now()
+	ebiten.SetWindowClosingHandled(true)
+
 	return nil
 }
 
-func loadVisual(visual *commands.SceneVisual, contentMap map[string]any, parent *commands.SceneVisual) {
+// loadProject loads our assets, content and scene from disk,
+// replacing any previously loaded project.
+func (s *EditorScene) loadProject() error {
+	var (
+		assetData   map[string]commands.Asset
+		contentData map[string]commands.Content
+		metadata    commands.Metadata
+	)
+
+	if err := commands.LoadAssets(&assetData); err != nil {
+		return err
+	}
+	if err := commands.LoadContent(&contentData); err != nil {
+		return err
+	}
+	if err := commands.LoadMetadata(&metadata); err != nil {
+		return err
+	}
+
+	var sceneData commands.SceneData
+	sceneFile, err := commands.LoadSceneFile(&sceneData, s.path)
+	if err != nil {
+		return fmt.Errorf("unable to load scene data: %w", err)
+	}
+
+	var prefabs map[string]*commands.SceneVisual
+	if err := commands.LoadPrefabs(&prefabs); err != nil {
+		return err
+	}
+
+	var stringTables commands.StringTables
+	if err := commands.LoadStrings(&stringTables); err != nil {
+		return err
+	}
+
+	sceneAssets, sceneContent, assetPaths, err := loadSceneContent(assetData, contentData, metadata)
+	if err != nil {
+		return err
+	}
+
+	watchPaths := []string{
+		filepath.Join(commands.InternalDir, commands.AssetsFile),
+		filepath.Join(commands.InternalDir, commands.ContentsFile),
+		filepath.Join(commands.InternalDir, s.path),
+		// new locales change our strings directory
+		filepath.Join(commands.InternalDir, commands.StringsDir),
+	}
+	watchPaths = append(watchPaths, assetPaths...)
+	for name := range prefabs {
+		watchPaths = append(watchPaths, commands.PrefabPath(name))
+	}
+	for locale := range stringTables {
+		watchPaths = append(watchPaths, commands.StringsPath(locale))
+	}
+
+	for _, t := range sceneData.Visuals {
+		if err := loadVisual(t, sceneContent, prefabs, nil); err != nil {
+			disposeContent(sceneContent)
+			disposeAssets(sceneAssets)
+			return err
+		}
+	}
+
+	disposeContent(s.sceneContent)
+	disposeAssets(s.sceneAssets)
+	s.sceneAssets = sceneAssets
+	s.sceneContent = sceneContent
+	s.assetData = assetData
+	s.contentData = contentData
+	s.metadata = metadata
+	s.stringTables = stringTables
+	if _, found := stringTables[s.locale]; !found {
+		s.locale = metadata.LocaleOrDefault()
+	}
+
+	s.watcher.Reset()
+	s.watcher.Watch(watchPaths...)
+	s.assetWatcher.Reset()
+	s.assetWatcher.Watch(assetPaths...)
+
+	s.sceneFile = sceneFile
+	s.sceneData = sceneData
+	s.activeVisual = nil
+	s.timeline = nil
+	s.dirty = false
+	commands.LocalizeVisuals(s, s.sceneData.Visuals)
+	width, height := s.PreviewSize()
+	resizeWindowVisuals(s.sceneData.Visuals, width, height)
+	return commands.ApplyBreakpoints(s)
+}
+
+// loadSceneContent loads our assets and builds our content from them,
+// along with the asset files we read so they can be watched.
+func loadSceneContent(
+	assetData map[string]commands.Asset,
+	contentData map[string]commands.Content,
+	metadata commands.Metadata,
+) (sceneAssets, sceneContent map[string]any, assetPaths []string, err error) {
+	sceneAssets = make(map[string]any)
+	for k, a := range assetData {
+		asset, assetPath, err := loadAsset(a, metadata)
+		if err != nil {
+			disposeAssets(sceneAssets)
+			return nil, nil, nil, err
+		}
+
+		if asset != nil {
+			sceneAssets[k] = asset
+			assetPaths = append(assetPaths, assetPath)
+		}
+	}
+
+	sceneContent = make(map[string]any)
+	for k, c := range contentData {
+		built, err := newContent(k, c, sceneAssets)
+		if err != nil {
+			disposeContent(sceneContent)
+			disposeAssets(sceneAssets)
+			return nil, nil, nil, err
+		}
+
+		if built != nil {
+			sceneContent[k] = built
+		}
+	}
+
+	return sceneAssets, sceneContent, assetPaths, nil
+}
+
+// loadAsset loads an image or font asset along with the file we read,
+// other assets such as sounds are not previewed and load as nil.
+func loadAsset(a commands.Asset, metadata commands.Metadata) (any, string, error) {
+	assetPath := filepath.Join(metadata.AssetsPath, a.File)
+
+	switch a.Type {
+	case commands.AssetImage:
+		img, _, err := ebitenutil.NewImageFromFile(assetPath)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return img, assetPath, nil
+	case commands.AssetOpenType:
+		fontBytes, err := os.ReadFile(assetPath)
+		if err != nil {
+			return nil, "", err
+		}
+
+		fontAsset, err := opentype.Parse(fontBytes)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return fontAsset, assetPath, nil
+	}
+
+	return nil, "", nil
+}
+
+// newContent builds content from our loaded assets,
+// fonts missing their asset are skipped and build as nil.
+func newContent(key string, c commands.Content, assets map[string]any) (any, error) {
+	switch c.Type {
+	case commands.ContentSprite:
+		img, ok := assets[c.Sprite.Asset].(*ebiten.Image)
+		if !ok {
+			return nil, fmt.Errorf("unable to load %v: image asset %v not found", key, c.Sprite.Asset)
+		}
+
+		return &content.Sprite{
+			Image: commands.SubImage(img, c.Sprite.Region),
+			// TODO: other sprite attributes
+		}, nil
+	case commands.ContentSliced:
+		img, ok := assets[c.Sliced.Asset].(*ebiten.Image)
+		if !ok {
+			return nil, fmt.Errorf("unable to load %v: image asset %v not found", key, c.Sliced.Asset)
+		}
+
+		return &components.SlicedSprite{
+			Image:   commands.SubImage(img, c.Sliced.Region),
+			Borders: c.Sliced.Borders,
+		}, nil
+	case commands.ContentAnimation:
+		animation, err := commands.NewAnimation(c.Animation, assets)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load %v: %w", key, err)
+		}
+
+		return animation, nil
+	case commands.ContentFont:
+		fontAsset, ok := assets[c.Font.Asset].(*opentype.Font)
+		if !ok {
+			return nil, nil
+		}
+
+		face, err := opentype.NewFace(fontAsset, &opentype.FaceOptions{
+			Size: float64(c.Font.Size),
+			DPI:  float64(c.Font.DPI),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &content.Font{
+			Face: face,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// contentAssets are the keys of the assets content is built from.
+func contentAssets(c commands.Content) []string {
+	switch c.Type {
+	case commands.ContentSprite:
+		return []string{c.Sprite.Asset}
+	case commands.ContentSliced:
+		return []string{c.Sliced.Asset}
+	case commands.ContentFont:
+		return []string{c.Font.Asset}
+	case commands.ContentAnimation:
+		keys := make([]string, 0, len(c.Animation.Frames))
+		for _, frame := range c.Animation.Frames {
+			keys = append(keys, frame.Asset)
+		}
+
+		return keys
+	}
+
+	return nil
+}
+
+// ReloadContent loads our assets and content again after we write them.
+// Only assets whose data or file changed are loaded again, along with the
+// content built from them, and only visuals using changed content are created
+// again. Our scene is kept and the files we wrote are no longer seen as changed.
+func (s *EditorScene) ReloadContent() error {
+	var (
+		assetData   map[string]commands.Asset
+		contentData map[string]commands.Content
+	)
+
+	if err := commands.LoadAssets(&assetData); err != nil {
+		return err
+	}
+	if err := commands.LoadContent(&contentData); err != nil {
+		return err
+	}
+
+	changedFiles := make(map[string]struct{})
+	for _, p := range s.assetWatcher.Changed() {
+		changedFiles[p] = struct{}{}
+	}
+
+	sceneAssets := make(map[string]any)
+	loadedAssets := make(map[string]any)
+	var assetPaths []string
+	for k, a := range assetData {
+		old, found := s.sceneAssets[k]
+		_, fileChanged := changedFiles[filepath.Join(s.metadata.AssetsPath, a.File)]
+		if found && s.assetData[k] == a && !fileChanged {
+			sceneAssets[k] = old
+			continue
+		}
+
+		asset, assetPath, err := loadAsset(a, s.metadata)
+		if err != nil {
+			disposeAssets(loadedAssets)
+			return err
+		}
+
+		if asset != nil {
+			sceneAssets[k] = asset
+			loadedAssets[k] = asset
+			assetPaths = append(assetPaths, assetPath)
+		}
+	}
+
+	changedAssets := replacedKeys(s.sceneAssets, sceneAssets)
+	for k := range loadedAssets {
+		changedAssets[k] = struct{}{}
+	}
+
+	sceneContent := make(map[string]any)
+	builtContent := make(map[string]any)
+	for k, c := range contentData {
+		old, found := s.sceneContent[k]
+		if found && reflect.DeepEqual(s.contentData[k], c) && !containsAny(changedAssets, contentAssets(c)) {
+			sceneContent[k] = old
+			continue
+		}
+
+		built, err := newContent(k, c, sceneAssets)
+		if err != nil {
+			disposeContent(builtContent)
+			disposeAssets(loadedAssets)
+			return err
+		}
+
+		if built != nil {
+			sceneContent[k] = built
+			builtContent[k] = built
+		}
+	}
+
+	changedContent := replacedKeys(s.sceneContent, sceneContent)
+	if err := s.rebuildVisuals(changedContent, sceneContent); err != nil {
+		disposeContent(builtContent)
+		disposeAssets(loadedAssets)
+		return err
+	}
+
+	// nothing draws what we replaced anymore
+	disposeContent(pickKeys(s.sceneContent, changedContent))
+	disposeAssets(pickKeys(s.sceneAssets, changedAssets))
+	s.sceneAssets = sceneAssets
+	s.sceneContent = sceneContent
+	s.assetData = assetData
+	s.contentData = contentData
+
+	s.assetWatcher.Watch(assetPaths...)
+	s.watcher.Watch(append(assetPaths,
+		filepath.Join(commands.InternalDir, commands.AssetsFile),
+		filepath.Join(commands.InternalDir, commands.ContentsFile),
+	)...)
+
+	if len(changedContent) == 0 {
+		return nil
+	}
+
+	commands.LocalizeVisuals(s, s.sceneData.Visuals)
+	width, height := s.PreviewSize()
+	resizeWindowVisuals(s.sceneData.Visuals, width, height)
+	return commands.ApplyBreakpoints(s)
+}
+
+// rebuildVisuals creates our root visuals using changed content again from
+// sceneContent. When one fails every root is created again from our current
+// content so none draw content we are about to dispose.
+func (s *EditorScene) rebuildVisuals(changed map[string]struct{}, sceneContent map[string]any) error {
+	var roots []*commands.SceneVisual
+	for _, t := range s.sceneData.Visuals {
+		if usesContent(t, changed) {
+			roots = append(roots, t)
+		}
+	}
+
+	if len(roots) == 0 {
+		return nil
+	}
+
+	var prefabs map[string]*commands.SceneVisual
+	if err := commands.LoadPrefabs(&prefabs); err != nil {
+		return err
+	}
+
+	for _, t := range roots {
+		if err := loadVisual(t, sceneContent, prefabs, nil); err != nil {
+			for _, v := range s.sceneData.Visuals {
+				_ = loadVisual(v, s.sceneContent, prefabs, nil)
+			}
+
+			return err
+		}
+	}
+
+	// our timeline animates the visuals we replaced
+	s.timeline = nil
+	return nil
+}
+
+// usesContent is whether a visual, its children or the prefab it expands to
+// use any of our content keys.
+func usesContent(visual *commands.SceneVisual, keys map[string]struct{}) bool {
+	if kind, err := commands.LookupVisualKind(visual.Type); err == nil {
+		refs, err := kind.Content(visual)
+		if err != nil {
+			return true
+		}
+
+		for _, ref := range refs {
+			if _, found := keys[ref.Key]; found {
+				return true
+			}
+		}
+	}
+
+	if visual.Expanded != nil && usesContent(visual.Expanded, keys) {
+		return true
+	}
+
+	for _, c := range visual.Children {
+		if usesContent(c, keys) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// replacedKeys are the keys of old that are missing from or replaced in current.
+func replacedKeys(old, current map[string]any) map[string]struct{} {
+	keys := make(map[string]struct{})
+	for k, v := range old {
+		if current[k] != v {
+			keys[k] = struct{}{}
+		}
+	}
+
+	return keys
+}
+
+func pickKeys(m map[string]any, keys map[string]struct{}) map[string]any {
+	picked := make(map[string]any, len(keys))
+	for k := range keys {
+		if v, found := m[k]; found {
+			picked[k] = v
+		}
+	}
+
+	return picked
+}
+
+func containsAny(set map[string]struct{}, keys []string) bool {
+	for _, k := range keys {
+		if _, found := set[k]; found {
+			return true
+		}
+	}
+
+	return false
+}
+
+func disposeContent(contentMap map[string]any) {
+	for _, c := range contentMap {
+		if font, ok := c.(*content.Font); ok {
+			font.Close()
+		}
+	}
+}
+
+func disposeAssets(assets map[string]any) {
+	for _, a := range assets {
+		if img, ok := a.(*ebiten.Image); ok {
+			img.Dispose()
+		}
+	}
+}
+
+// watchProject reloads our project when files change on disk,
+// unless we have unsaved changes that a reload would throw away.
+func (s *EditorScene) watchProject() {
+	if time.Since(s.lastWatch) < watchInterval {
+		return
+	}
+	s.lastWatch = time.Now()
+
+	changed := s.watcher.Changed()
+	if len(changed) == 0 {
+		return
+	}
+
+	if s.dirty {
+		s.inputResponse.SetText(fmt.Sprintf(
+			"conflict, changed on disk with unsaved changes:\n%v\nrun reload to discard yours or diff to compare",
+			strings.Join(changed, "\n"),
+		))
+		return
+	}
+
+	if err := s.Reload(); err != nil {
+		s.inputResponse.SetText(fmt.Sprintf("unable to reload: %v", err))
+		return
+	}
+
+	s.inputResponse.SetText("reloaded:\n" + strings.Join(changed, "\n"))
+}
+
+func loadVisual(
+	visual *commands.SceneVisual,
+	contentMap map[string]any,
+	prefabs map[string]*commands.SceneVisual,
+	parent *commands.SceneVisual,
+) error {
 	ww, wh := igloo.GetWindowSize()
 	windowWidth := float64(ww)
 	windowHeight := float64(wh)
 
 	var newVis *igloo.Visualer
 
-	switch visual.Type {
-	case commands.EmptyVisualType:
-		newVis = graphics.NewEmptyVisual().Visualer
-	case commands.SpriteVisualType:
-		spriteContent := contentMap[visual.Sprite.Content].(*content.Sprite)
-		spriteVis := graphics.NewSpriteVisual()
-		spriteVis.SetSprite(spriteContent)
-		newVis = spriteVis.Visualer
+	if visual.Type == commands.PrefabVisualType {
+		expanded, err := commands.ExpandPrefab(visual, prefabs)
+		if err != nil {
+			return err
+		}
+
+		// the prefab visuals are only previewed, our instance is what we edit
+		if err := loadVisual(expanded, contentMap, prefabs, nil); err != nil {
+			return err
+		}
+
+		newVis = expanded.Visual
+		visual.Instance = expanded.Instance
+		visual.Expanded = expanded
+	} else {
+		kind, err := commands.LookupVisualKind(visual.Type)
+		if err != nil {
+			return err
+		}
+
+		visual.Instance, newVis, err = kind.New(visual, contentMap)
+		if err != nil {
+			return fmt.Errorf("unable to create %v: %w", visual.Name, err)
+		}
 	}
 
 	visual.Visual = newVis
+	if err := visual.ApplyTint(&newVis.ColorM); err != nil {
+		return fmt.Errorf("unable to tint %v: %w", visual.Name, err)
+	}
+
 	newVis.SetVisible(visual.Visible)
 	newVis.SetPosition(visual.Transform.Position)
 	newVis.SetAnchors(visual.Transform.Anchors)
 	newVis.SetOffsets(visual.Transform.Offsets)
 	newVis.SetPivot(visual.Transform.Pivot)
 	newVis.SetRotation(visual.Transform.Rotation)
+	newVis.SetScale(visual.Transform.ScaleOrOne())
 
 	if visual.UseWindowSize {
 		newVis.SetWidth(windowWidth)
@@ -245,17 +757,37 @@ func loadVisual(visual *commands.SceneVisual, contentMap map[string]any, parent
 	}
 
 	if parent != nil {
-		parent.Visual.InsertChild(newVis)
+		// containers place their own children
+		if container, ok := parent.Instance.(interface{ AddChild(*igloo.Visualer) }); ok {
+			container.AddChild(newVis)
+		} else {
+			parent.Visual.InsertChild(newVis)
+		}
 		visual.Parent = parent
 	}
 
 	for _, child := range visual.Children {
-		loadVisual(child, contentMap, visual)
+		if err := loadVisual(child, contentMap, prefabs, visual); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
 func (s *EditorScene) Update() {
+	if ebiten.IsWindowBeingClosed() {
+		s.requestQuit()
+	}
+
+	s.autosave()
+	s.watchProject()
+	updateVisuals(s.sceneData.Visuals)
+	if s.timeline != nil {
+		s.timeline.Update()
+	}
 	s.commandInput.Update()
+	s.pickVisual()
 
 	if s.commandInput.State.Current() == components.TextEditorClosed {
 		dir := mathf.Vec2Zero
@@ -277,22 +809,150 @@ func (s *EditorScene) Update() {
 	}
 }
 
+// pickVisual selects the topmost visual under the cursor when clicking,
+// alt clicking cycles through every visual under the cursor.
+func (s *EditorScene) pickVisual() {
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || s.cursorOverEditor() {
+		return
+	}
+
+	hits := visualsUnderCursor(s.sceneData.Visuals)
+	if len(hits) == 0 {
+		return
+	}
+
+	// hits are in draw order so the last is on top
+	picked := hits[len(hits)-1]
+	if ebiten.IsKeyPressed(ebiten.KeyAlt) {
+		for i, v := range hits {
+			if v == s.activeVisual {
+				picked = hits[(i+len(hits)-1)%len(hits)]
+				break
+			}
+		}
+	}
+
+	s.SetVisual(picked)
+	s.inputResponse.SetText("selected " + visualPath(picked))
+}
+
+// cursorOverEditor is whether the cursor is over our status or command labels.
+func (s *EditorScene) cursorOverEditor() bool {
+	if components.ContainsCursor(s.statusLabel.Visualer) {
+		return true
+	}
+
+	if !s.inputRoot.Visible() {
+		return false
+	}
+
+	return components.ContainsCursor(s.textInputLabel.Visualer) ||
+		components.ContainsCursor(s.suggestionsLabel.Visualer) ||
+		components.ContainsCursor(s.inputResponse.Visualer)
+}
+
+// visualsUnderCursor are the visible visuals containing the cursor in draw order,
+// hidden visuals hide their children as well.
+func visualsUnderCursor(visuals []*commands.SceneVisual) []*commands.SceneVisual {
+	var hits []*commands.SceneVisual
+	for _, v := range visuals {
+		if v.Visual == nil || !v.Visual.Visible() {
+			continue
+		}
+
+		if components.ContainsCursor(v.Visual) {
+			hits = append(hits, v)
+		}
+
+		hits = append(hits, visualsUnderCursor(v.Children)...)
+	}
+
+	return hits
+}
+
+// visualPath is the names of our visual and its parents separated by slashes.
+func visualPath(visual *commands.SceneVisual) string {
+	path := visual.Name
+	for p := visual.Parent; p != nil; p = p.Parent {
+		path = p.Name + "/" + path
+	}
+
+	return path
+}
+
+// updateVisuals advances visuals that change over time, such as animations.
+func updateVisuals(visuals []*commands.SceneVisual) {
+	for _, v := range visuals {
+		if updater, ok := v.Instance.(interface{ Update() }); ok {
+			updater.Update()
+		}
+
+		updateVisuals(v.Children)
+	}
+}
+
 func (s *EditorScene) Draw(dest *ebiten.Image) {
 	for _, v := range s.sceneData.Visuals {
 		v.Visual.Layout(v.Visual.Transform, nil)
 		v.Visual.Draw(dest)
 	}
 
+	s.overlay.Draw(dest, s.activeVisual)
+
+	s.statusRoot.Visualer.Layout(s.statusRoot.Transform, nil)
+	s.statusRoot.Visualer.Draw(dest)
+
 	s.inputRoot.Visualer.Layout(s.inputRoot.Transform, nil)
 	s.inputRoot.Visualer.Draw(dest)
 }
 
+// requestQuit closes the editor unless there are unsaved changes,
+// in which case a second request is needed to confirm.
+func (s *EditorScene) requestQuit() {
+	if !s.dirty || s.confirmQuit {
+		s.Quit()
+		return
+	}
+
+	s.confirmQuit = true
+	s.commandInput.State.Transition(components.TextEditorOpen)
+	s.inputResponse.SetText("scene has unsaved changes\nclose again to discard them or run write")
+}
+
+func (s *EditorScene) autosave() {
+	interval := time.Duration(s.metadata.AutosaveSeconds) * time.Second
+	if interval <= 0 || !s.dirty || time.Since(s.lastSave) < interval {
+		return
+	}
+
+	err := commands.CheckSceneFile(s.path, s.sceneFile)
+	if err == nil {
+		s.sceneFile, err = commands.SaveSceneData(&s.sceneData, s.path, s.metadata.BackupCount())
+	}
+
+	if err != nil {
+		s.inputResponse.SetText(fmt.Sprintf("unable to autosave: %v", err))
+		s.lastSave = time.Now()
+		return
+	}
+
+	s.SetDirty(false)
+}
+
+func (s *EditorScene) updateStatus() {
+	if s.dirty {
+		s.statusLabel.SetText(s.path + " *")
+	} else {
+		s.statusLabel.SetText(s.path)
+	}
+}
+
 func (s *EditorScene) Dispose() {
 	s.assets.Dispose()
 	s.content.Dispose()
-	for _, h := range s.disposeHandlers {
-		h()
-	}
+	disposeContent(s.sceneContent)
+	disposeAssets(s.sceneAssets)
+	s.overlay.Dispose()
 }
 
 // commands.Editor implementations
@@ -305,6 +965,52 @@ func (s *EditorScene) SetVisual(visual *commands.SceneVisual) {
 	s.activeVisual = visual
 }
 
+func (s *EditorScene) SetTimeline(timeline *components.Timeline) {
+	s.timeline = timeline
+}
+
+func (s *EditorScene) Strings() commands.StringTables {
+	return s.stringTables
+}
+
+func (s *EditorScene) Locale() string {
+	return s.locale
+}
+
+func (s *EditorScene) SetLocale(locale string) {
+	s.locale = locale
+	commands.LocalizeVisuals(s, s.sceneData.Visuals)
+}
+
+func (s *EditorScene) PreviewSize() (float64, float64) {
+	if s.previewWidth > 0 && s.previewHeight > 0 {
+		return s.previewWidth, s.previewHeight
+	}
+
+	ww, wh := igloo.GetWindowSize()
+	return float64(ww), float64(wh)
+}
+
+func (s *EditorScene) SetPreviewSize(width, height float64) {
+	s.previewWidth = width
+	s.previewHeight = height
+
+	width, height = s.PreviewSize()
+	resizeWindowVisuals(s.sceneData.Visuals, width, height)
+}
+
+// resizeWindowVisuals sets the size of visuals using the window size.
+func resizeWindowVisuals(visuals []*commands.SceneVisual, width, height float64) {
+	for _, v := range visuals {
+		if v.UseWindowSize && v.Visual != nil {
+			v.Visual.SetWidth(width)
+			v.Visual.SetHeight(height)
+		}
+
+		resizeWindowVisuals(v.Children, width, height)
+	}
+}
+
 func (s *EditorScene) Path() string {
 	return s.path
 }
@@ -313,6 +1019,55 @@ func (s *EditorScene) SceneData() *commands.SceneData {
 	return &s.sceneData
 }
 
+func (s *EditorScene) Content() map[string]any {
+	return s.sceneContent
+}
+
+func (s *EditorScene) ContentType(key string) commands.ContentType {
+	return s.contentData[key].Type
+}
+
+func (s *EditorScene) SceneFile() commands.SceneFileInfo {
+	return s.sceneFile
+}
+
+func (s *EditorScene) SetSceneFile(info commands.SceneFileInfo) {
+	s.sceneFile = info
+}
+
+func (s *EditorScene) Metadata() *commands.Metadata {
+	return &s.metadata
+}
+
+func (s *EditorScene) Reload() error {
+	if err := s.loadProject(); err != nil {
+		return err
+	}
+
+	s.SetDirty(false)
+	return nil
+}
+
 func (s *EditorScene) Commands() *commands.Commands {
 	return s.commands
 }
+
+func (s *EditorScene) Dirty() bool {
+	return s.dirty
+}
+
+func (s *EditorScene) SetDirty(dirty bool) {
+	if !dirty {
+		// we are in sync with the scene on disk again
+		s.lastSave = time.Now()
+		s.watcher.Watch(filepath.Join(commands.InternalDir, s.path))
+	}
+
+	s.dirty = dirty
+	s.updateStatus()
+}
+
+func (s *EditorScene) Quit() {
+	s.Dispose()
+	os.Exit(0)
+}
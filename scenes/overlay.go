@@ -0,0 +1,99 @@
+package scenes
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/miniscruff/igloo/mathf"
+	"github.com/miniscruff/inuit/commands"
+)
+
+const markerSize = 6
+
+var (
+	outlineColor = color.RGBA{R: 0xff, G: 0xd7, A: 0xff}
+	pivotColor   = color.RGBA{R: 0xff, B: 0xff, A: 0xff}
+	anchorColor  = color.RGBA{G: 0xd7, B: 0xff, A: 0xff}
+)
+
+// editorOverlay draws gizmos of our active visual on a layer of its own,
+// it is only part of our editor and never saved with our scene.
+type editorOverlay struct {
+	layer *ebiten.Image
+}
+
+// Draw outlines our visual, marks its pivot and shows its anchors
+// within its parent, or within dest for root visuals.
+func (o *editorOverlay) Draw(dest *ebiten.Image, visual *commands.SceneVisual) {
+	if visual == nil || visual.Visual == nil {
+		return
+	}
+
+	size := dest.Bounds().Size()
+	if o.layer == nil || o.layer.Bounds().Size() != size {
+		if o.layer != nil {
+			o.layer.Dispose()
+		}
+
+		o.layer = ebiten.NewImage(size.X, size.Y)
+	}
+
+	o.layer.Clear()
+
+	parent := mathf.Bounds{Width: float64(size.X), Height: float64(size.Y)}
+	if visual.Parent != nil && visual.Parent.Visual != nil {
+		parent = visual.Parent.Visual.Transform.Bounds()
+	}
+
+	// breakpoints and timelines can change our anchors from their saved values
+	anchors := visual.Visual.Transform.Anchors()
+	anchorRect := mathf.Bounds{
+		X:      parent.X + anchors.Left*parent.Width,
+		Y:      parent.Y + anchors.Top*parent.Height,
+		Width:  (anchors.Right - anchors.Left) * parent.Width,
+		Height: (anchors.Bottom - anchors.Top) * parent.Height,
+	}
+
+	if anchorRect.Width != 0 || anchorRect.Height != 0 {
+		drawOutline(o.layer, anchorRect, anchorColor)
+	}
+
+	for _, corner := range []mathf.Vec2{
+		{X: anchorRect.X, Y: anchorRect.Y},
+		{X: anchorRect.Right(), Y: anchorRect.Y},
+		{X: anchorRect.X, Y: anchorRect.Bottom()},
+		{X: anchorRect.Right(), Y: anchorRect.Bottom()},
+	} {
+		drawMarker(o.layer, corner, anchorColor)
+	}
+
+	bounds := visual.Visual.Transform.Bounds()
+	drawOutline(o.layer, bounds, outlineColor)
+
+	pivot := visual.Visual.Transform.Pivot()
+	drawMarker(o.layer, mathf.Vec2{
+		X: bounds.X + pivot.X*bounds.Width,
+		Y: bounds.Y + pivot.Y*bounds.Height,
+	}, pivotColor)
+
+	dest.DrawImage(o.layer, nil)
+}
+
+func (o *editorOverlay) Dispose() {
+	if o.layer != nil {
+		o.layer.Dispose()
+		o.layer = nil
+	}
+}
+
+func drawOutline(dest *ebiten.Image, bounds mathf.Bounds, clr color.Color) {
+	ebitenutil.DrawLine(dest, bounds.X, bounds.Y, bounds.Right(), bounds.Y, clr)
+	ebitenutil.DrawLine(dest, bounds.Right(), bounds.Y, bounds.Right(), bounds.Bottom(), clr)
+	ebitenutil.DrawLine(dest, bounds.Right(), bounds.Bottom(), bounds.X, bounds.Bottom(), clr)
+	ebitenutil.DrawLine(dest, bounds.X, bounds.Bottom(), bounds.X, bounds.Y, clr)
+}
+
+func drawMarker(dest *ebiten.Image, at mathf.Vec2, clr color.Color) {
+	ebitenutil.DrawRect(dest, at.X-markerSize/2, at.Y-markerSize/2, markerSize, markerSize, clr)
+}